@@ -0,0 +1,18 @@
+package api
+
+import "errors"
+
+// ErrNilElasticClient signals that a nil elastic client has been provided
+var ErrNilElasticClient = errors.New("nil elastic client")
+
+// ErrNilAccountsGetter signals that a nil accounts getter has been provided
+var ErrNilAccountsGetter = errors.New("nil accounts getter")
+
+// ErrEmptyIndex signals that no accounts index or alias has been provided
+var ErrEmptyIndex = errors.New("empty accounts index")
+
+// ErrInvalidLimit signals that an invalid batch or top limit has been provided
+var ErrInvalidLimit = errors.New("invalid limit")
+
+// ErrNoSnapshot signals that the accounts index has not been created yet
+var ErrNoSnapshot = errors.New("no accounts snapshot")
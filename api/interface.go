@@ -0,0 +1,18 @@
+package api
+
+import "github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+
+// ElasticClientHandler defines what an elastic client should be able to do for the query API
+type ElasticClientHandler interface {
+	GetAliasIndices(alias string) ([]string, error)
+	CheckIfIndexExists(index string) (bool, error)
+	DoMultiGet(ids []string, index string) ([]byte, error)
+	DoSearchRequest(index string, body []byte) ([]byte, error)
+	IsInterfaceNil() bool
+}
+
+// AccountsGetter defines what an accounts' getter should be able to do. The accounts that cannot be read should be
+// reported with an error, not left out of the returned map
+type AccountsGetter interface {
+	GetAccountsStrict(addresses []string, index string) (map[string]*data.AccountInfoWithStakeValues, error)
+}
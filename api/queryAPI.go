@@ -0,0 +1,339 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	logger "github.com/multiversx/mx-chain-logger-go"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/tidwall/gjson"
+)
+
+const (
+	valuesIndex        = "values"
+	defaultTopSize     = 100
+	maxRequestBodySize = 1 << 20
+)
+
+var log = logger.GetOrCreate("api")
+
+// topFields holds the numeric fields the accounts can be ranked by
+var topFields = map[string]struct{}{
+	"balanceNum":                 {},
+	"totalBalanceWithStakeNum":   {},
+	"totalStakeNum":              {},
+	"totalUnDelegateNum":         {},
+	"delegationNum":              {},
+	"delegationLegacyActiveNum":  {},
+	"delegationLegacyWaitingNum": {},
+	"validatorsActiveNum":        {},
+	"validatorsTopUpNum":         {},
+	"energyNum":                  {},
+	"lkMexStakeNum":              {},
+}
+
+// ArgsQueryAPI holds the arguments needed to create a new query API
+type ArgsQueryAPI struct {
+	ElasticClient  ElasticClientHandler
+	AccountsGetter AccountsGetter
+	Index          string
+	MaxBatchSize   int
+	MaxTopSize     int
+}
+
+type queryAPI struct {
+	elasticClient  ElasticClientHandler
+	accountsGetter AccountsGetter
+	index          string
+	maxBatchSize   int
+	maxTopSize     int
+}
+
+type batchRequest struct {
+	Addresses []string `json:"addresses"`
+}
+
+type snapshotResponse struct {
+	Index         string                    `json:"index"`
+	Epoch         uint32                    `json:"epoch"`
+	SnapshotBlock json.RawMessage           `json:"snapshotBlock,omitempty"`
+	EnergyBlock   string                    `json:"energyBlockHash,omitempty"`
+	Stats         *crossIndex.SnapshotStats `json:"stats"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// NewQueryAPI will create a new instance of the read-only query API over an accounts index or alias
+func NewQueryAPI(args ArgsQueryAPI) (*queryAPI, error) {
+	if check.IfNil(args.ElasticClient) {
+		return nil, ErrNilElasticClient
+	}
+	if args.AccountsGetter == nil {
+		return nil, ErrNilAccountsGetter
+	}
+	if args.Index == "" {
+		return nil, ErrEmptyIndex
+	}
+	if args.MaxBatchSize < 1 || args.MaxTopSize < 1 {
+		return nil, ErrInvalidLimit
+	}
+
+	return &queryAPI{
+		elasticClient:  args.ElasticClient,
+		accountsGetter: args.AccountsGetter,
+		index:          args.Index,
+		maxBatchSize:   args.MaxBatchSize,
+		maxTopSize:     args.MaxTopSize,
+	}, nil
+}
+
+// Handler will return the http handler that serves all the routes of the query API
+func (qa *queryAPI) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/accounts/", qa.getAccount)
+	mux.HandleFunc("/accounts", qa.getAccounts)
+	mux.HandleFunc("/snapshot", qa.getSnapshot)
+	mux.HandleFunc("/top", qa.getTop)
+
+	return mux
+}
+
+// getAccount handles GET /accounts/{address}
+func (qa *queryAPI) getAccount(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+		return
+	}
+
+	address := strings.TrimPrefix(r.URL.Path, "/accounts/")
+	if address == "" || strings.Contains(address, "/") {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid address %q", address))
+		return
+	}
+
+	accounts, err := qa.accountsGetter.GetAccountsStrict([]string{address}, qa.index)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	account, ok := accounts[address]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("account %s not found", address))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, account)
+}
+
+// getAccounts handles POST /accounts, with a {"addresses": [...]} body. The found accounts are returned by address
+func (qa *queryAPI) getAccounts(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+		return
+	}
+
+	request := &batchRequest{}
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBodySize)).Decode(request)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	if len(request.Addresses) == 0 || len(request.Addresses) > qa.maxBatchSize {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("the number of addresses should be between 1 and %d", qa.maxBatchSize))
+		return
+	}
+
+	accounts, err := qa.accountsGetter.GetAccountsStrict(request.Addresses, qa.index)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, accounts)
+}
+
+// getSnapshot handles GET /snapshot and returns the epoch, the blocks and the totals of the queried accounts index
+func (qa *queryAPI) getSnapshot(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+		return
+	}
+
+	response, err := qa.createSnapshotResponse()
+	if errors.Is(err, ErrNoSnapshot) {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (qa *queryAPI) createSnapshotResponse() (*snapshotResponse, error) {
+	index, err := qa.resolveIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := qa.elasticClient.CheckIfIndexExists(index)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("%w: index %s does not exist", ErrNoSnapshot, index)
+	}
+
+	epoch, err := epochFromIndex(index)
+	if err != nil {
+		return nil, err
+	}
+
+	statsBytes, err := qa.elasticClient.DoSearchRequest(index, crossIndex.GetSnapshotStats().Bytes())
+	if err != nil {
+		return nil, err
+	}
+	stats, err := crossIndex.ParseSnapshotStats(statsBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &snapshotResponse{
+		Index: index,
+		Epoch: epoch,
+		Stats: stats,
+	}
+
+	// the values index is created only after the first snapshot was published
+	valuesExists, err := qa.elasticClient.CheckIfIndexExists(valuesIndex)
+	if err != nil {
+		return nil, err
+	}
+	if !valuesExists {
+		return response, nil
+	}
+
+	valuesBytes, err := qa.elasticClient.DoMultiGet([]string{
+		fmt.Sprintf("snapshot-block-%d", epoch),
+		fmt.Sprintf("energy-snapshot-%d", epoch),
+	}, valuesIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshotBlock := gjson.GetBytes(valuesBytes, "docs.0._source.value")
+	if snapshotBlock.Exists() {
+		response.SnapshotBlock = json.RawMessage(snapshotBlock.String())
+	}
+	response.EnergyBlock = gjson.GetBytes(valuesBytes, "docs.1._source.value").String()
+
+	return response, nil
+}
+
+// resolveIndex returns the index the configured alias points to, or the configured index if it is not an alias
+func (qa *queryAPI) resolveIndex() (string, error) {
+	indices, err := qa.elasticClient.GetAliasIndices(qa.index)
+	if err != nil {
+		return "", err
+	}
+	if len(indices) > 1 {
+		return "", fmt.Errorf("the alias %s points to more than one index: %v", qa.index, indices)
+	}
+	if len(indices) == 1 {
+		return indices[0], nil
+	}
+
+	return qa.index, nil
+}
+
+// getTop handles GET /top?field=totalStakeNum&n=100
+func (qa *queryAPI) getTop(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+		return
+	}
+
+	field := r.URL.Query().Get("field")
+	if _, ok := topFields[field]; !ok {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid field %q", field))
+		return
+	}
+
+	size := defaultTopSize
+	if sizeParam := r.URL.Query().Get("n"); sizeParam != "" {
+		var err error
+		size, err = strconv.Atoi(sizeParam)
+		if err != nil || size < 1 || size > qa.maxTopSize {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("n should be between 1 and %d", qa.maxTopSize))
+			return
+		}
+	}
+
+	responseBytes, err := qa.elasticClient.DoSearchRequest(qa.index, crossIndex.GetTopAccounts(field, size).Bytes())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	accountsResponse := &crossIndex.AllAccountsResponse{}
+	err = json.Unmarshal(responseBytes, accountsResponse)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	accounts := make([]*data.AccountInfoWithStakeValues, 0, len(accountsResponse.Hits.Hits))
+	for idx := range accountsResponse.Hits.Hits {
+		accounts = append(accounts, &accountsResponse.Hits.Hits[idx].Account)
+	}
+
+	writeJSON(w, http.StatusOK, accounts)
+}
+
+func epochFromIndex(index string) (uint32, error) {
+	separatorIdx := strings.LastIndex(index, "_")
+	if separatorIdx < 0 {
+		return 0, fmt.Errorf("cannot extract the epoch from index %s", index)
+	}
+
+	epoch, err := strconv.ParseUint(index[separatorIdx+1:], 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("cannot extract the epoch from index %s: %w", index, err)
+	}
+
+	return uint32(epoch), nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	err := json.NewEncoder(w).Encode(value)
+	if err != nil {
+		log.Warn("cannot write response", "error", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	if status >= http.StatusInternalServerError {
+		log.Warn("query API request failed", "error", err)
+	}
+
+	writeJSON(w, status, &errorResponse{Error: err.Error()})
+}
+
+// IsInterfaceNil returns true if the value under the interface is nil
+func (qa *queryAPI) IsInterfaceNil() bool {
+	return qa == nil
+}
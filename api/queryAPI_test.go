@@ -0,0 +1,285 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/mocks"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/process/accountsIndexer"
+	"github.com/stretchr/testify/require"
+)
+
+func createArgs() ArgsQueryAPI {
+	return ArgsQueryAPI{
+		ElasticClient: &mocks.ElasticClientStub{},
+		AccountsGetter: &mocks.AccountsIndexerStub{
+			GetAccountsStrictCalled: func(addresses []string, index string) (map[string]*data.AccountInfoWithStakeValues, error) {
+				accounts := make(map[string]*data.AccountInfoWithStakeValues)
+				for _, address := range addresses {
+					if address == "erd1missing" {
+						continue
+					}
+					account := &data.AccountInfoWithStakeValues{StakeInfo: data.StakeInfo{TotalStake: "10"}}
+					account.Address = address
+					accounts[address] = account
+				}
+				return accounts, nil
+			},
+		},
+		Index:        "accounts-with-stake",
+		MaxBatchSize: 2,
+		MaxTopSize:   10,
+	}
+}
+
+func doRequest(t *testing.T, handler http.Handler, method string, target string, body string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(method, target, strings.NewReader(body)))
+
+	require.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	return recorder
+}
+
+func TestNewQueryAPI(t *testing.T) {
+	t.Parallel()
+
+	args := createArgs()
+	args.ElasticClient = nil
+	_, err := NewQueryAPI(args)
+	require.Equal(t, ErrNilElasticClient, err)
+
+	args = createArgs()
+	args.AccountsGetter = nil
+	_, err = NewQueryAPI(args)
+	require.Equal(t, ErrNilAccountsGetter, err)
+
+	args = createArgs()
+	args.Index = ""
+	_, err = NewQueryAPI(args)
+	require.Equal(t, ErrEmptyIndex, err)
+
+	args = createArgs()
+	args.MaxTopSize = 0
+	_, err = NewQueryAPI(args)
+	require.Equal(t, ErrInvalidLimit, err)
+
+	qa, err := NewQueryAPI(createArgs())
+	require.Nil(t, err)
+	require.False(t, qa.IsInterfaceNil())
+}
+
+func TestQueryAPI_GetAccount(t *testing.T) {
+	t.Parallel()
+
+	qa, _ := NewQueryAPI(createArgs())
+	handler := qa.Handler()
+
+	recorder := doRequest(t, handler, http.MethodGet, "/accounts/erd1a", "")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	account := &data.AccountInfoWithStakeValues{}
+	_ = json.Unmarshal(recorder.Body.Bytes(), account)
+	require.Equal(t, "erd1a", account.Address)
+	require.Equal(t, "10", account.TotalStake)
+
+	recorder = doRequest(t, handler, http.MethodGet, "/accounts/erd1missing", "")
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+
+	recorder = doRequest(t, handler, http.MethodDelete, "/accounts/erd1a", "")
+	require.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
+
+func TestQueryAPI_GetAccounts(t *testing.T) {
+	t.Parallel()
+
+	qa, _ := NewQueryAPI(createArgs())
+	handler := qa.Handler()
+
+	recorder := doRequest(t, handler, http.MethodPost, "/accounts", `{"addresses":["erd1a","erd1missing"]}`)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	accounts := make(map[string]*data.AccountInfoWithStakeValues)
+	_ = json.Unmarshal(recorder.Body.Bytes(), &accounts)
+	require.Len(t, accounts, 1)
+	require.Equal(t, "erd1a", accounts["erd1a"].Address)
+
+	recorder = doRequest(t, handler, http.MethodPost, "/accounts", `{"addresses":["erd1a","erd1b","erd1c"]}`)
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	recorder = doRequest(t, handler, http.MethodPost, "/accounts", `not json`)
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestQueryAPI_GetAccountsError(t *testing.T) {
+	t.Parallel()
+
+	args := createArgs()
+	args.AccountsGetter = &mocks.AccountsIndexerStub{
+		GetAccountsStrictCalled: func(_ []string, _ string) (map[string]*data.AccountInfoWithStakeValues, error) {
+			return nil, errors.New("local error")
+		},
+	}
+	qa, _ := NewQueryAPI(args)
+
+	recorder := doRequest(t, qa.Handler(), http.MethodGet, "/accounts/erd1a", "")
+	require.Equal(t, http.StatusInternalServerError, recorder.Code)
+	require.Equal(t, "{\"error\":\"local error\"}\n", recorder.Body.String())
+}
+
+func TestQueryAPI_GetAccountsShouldFailWhenTheMultiGetFails(t *testing.T) {
+	t.Parallel()
+
+	esClient := &mocks.ElasticClientStub{
+		DoMultiGetCalled: func(_ []string, _ string) ([]byte, error) {
+			return nil, errors.New("cluster unavailable")
+		},
+	}
+	acIndexer, err := accountsIndexer.NewAccountsIndexer(esClient, config.BulkIndexerConfig{})
+	require.Nil(t, err)
+
+	args := createArgs()
+	args.AccountsGetter = acIndexer
+	qa, _ := NewQueryAPI(args)
+	handler := qa.Handler()
+
+	recorder := doRequest(t, handler, http.MethodGet, "/accounts/erd1a", "")
+	require.Equal(t, http.StatusInternalServerError, recorder.Code)
+	require.Contains(t, recorder.Body.String(), "cluster unavailable")
+
+	recorder = doRequest(t, handler, http.MethodPost, "/accounts", `{"addresses":["erd1a","erd1b"]}`)
+	require.Equal(t, http.StatusInternalServerError, recorder.Code)
+	require.Contains(t, recorder.Body.String(), "cluster unavailable")
+}
+
+func TestQueryAPI_GetSnapshot(t *testing.T) {
+	t.Parallel()
+
+	args := createArgs()
+	args.ElasticClient = &mocks.ElasticClientStub{
+		GetAliasIndicesCalled: func(alias string) ([]string, error) {
+			require.Equal(t, "accounts-with-stake", alias)
+			return []string{"accounts-000001_12"}, nil
+		},
+		CheckIfIndexExistsCalled: func(index string) (bool, error) {
+			return true, nil
+		},
+		DoSearchRequestCalled: func(index string, _ []byte) ([]byte, error) {
+			require.Equal(t, "accounts-000001_12", index)
+			return []byte(`{"hits":{"total":{"value":3}},"aggregations":{"totalStake":{"value":30},"delegators":{"doc_count":2}}}`), nil
+		},
+		DoMultiGetCalled: func(ids []string, index string) ([]byte, error) {
+			require.Equal(t, valuesIndex, index)
+			require.Equal(t, []string{"snapshot-block-12", "energy-snapshot-12"}, ids)
+			return []byte(`{"docs":[{"found":true,"_source":{"key":"snapshotBlock","value":"{\"epoch\":12}"}},{"found":true,"_source":{"key":"blockHash","value":"abcd"}}]}`), nil
+		},
+	}
+	qa, _ := NewQueryAPI(args)
+
+	recorder := doRequest(t, qa.Handler(), http.MethodGet, "/snapshot", "")
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	response := &snapshotResponse{}
+	err := json.Unmarshal(recorder.Body.Bytes(), response)
+	require.Nil(t, err)
+	require.Equal(t, "accounts-000001_12", response.Index)
+	require.Equal(t, uint32(12), response.Epoch)
+	require.JSONEq(t, `{"epoch":12}`, string(response.SnapshotBlock))
+	require.Equal(t, "abcd", response.EnergyBlock)
+	require.Equal(t, float64(3), response.Stats.NumAccounts)
+	require.Equal(t, float64(30), response.Stats.Sums["totalStake"])
+	require.Equal(t, float64(2), response.Stats.SourceAccounts["delegators"])
+}
+
+func TestQueryAPI_GetSnapshotWithoutValuesIndexShouldReturnTheStats(t *testing.T) {
+	t.Parallel()
+
+	args := createArgs()
+	args.ElasticClient = &mocks.ElasticClientStub{
+		GetAliasIndicesCalled: func(alias string) ([]string, error) {
+			return []string{"accounts-000001_12"}, nil
+		},
+		CheckIfIndexExistsCalled: func(index string) (bool, error) {
+			return index != valuesIndex, nil
+		},
+		DoSearchRequestCalled: func(index string, _ []byte) ([]byte, error) {
+			return []byte(`{"hits":{"total":{"value":3}},"aggregations":{"totalStake":{"value":30}}}`), nil
+		},
+		DoMultiGetCalled: func(ids []string, index string) ([]byte, error) {
+			require.Fail(t, "should have not read the values index")
+			return nil, nil
+		},
+	}
+	qa, _ := NewQueryAPI(args)
+
+	recorder := doRequest(t, qa.Handler(), http.MethodGet, "/snapshot", "")
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	response := &snapshotResponse{}
+	err := json.Unmarshal(recorder.Body.Bytes(), response)
+	require.Nil(t, err)
+	require.Equal(t, uint32(12), response.Epoch)
+	require.Empty(t, response.SnapshotBlock)
+	require.Equal(t, "", response.EnergyBlock)
+	require.Equal(t, float64(3), response.Stats.NumAccounts)
+}
+
+func TestQueryAPI_GetSnapshotOnAFreshClusterShouldReturnNotFound(t *testing.T) {
+	t.Parallel()
+
+	args := createArgs()
+	args.ElasticClient = &mocks.ElasticClientStub{
+		DoSearchRequestCalled: func(index string, _ []byte) ([]byte, error) {
+			require.Fail(t, "should have not searched a missing index")
+			return nil, nil
+		},
+	}
+	qa, _ := NewQueryAPI(args)
+
+	recorder := doRequest(t, qa.Handler(), http.MethodGet, "/snapshot", "")
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+}
+
+func TestQueryAPI_GetTop(t *testing.T) {
+	t.Parallel()
+
+	args := createArgs()
+	args.ElasticClient = &mocks.ElasticClientStub{
+		DoSearchRequestCalled: func(index string, body []byte) ([]byte, error) {
+			require.Equal(t, "accounts-with-stake", index)
+			require.Equal(t, crossIndex.GetTopAccounts("totalStakeNum", 2).String(), string(body))
+			return []byte(`{"hits":{"hits":[{"_id":"erd1b","_source":{"address":"erd1b","totalStakeNum":20}},{"_id":"erd1a","_source":{"address":"erd1a","totalStakeNum":10}}]}}`), nil
+		},
+	}
+	qa, _ := NewQueryAPI(args)
+	handler := qa.Handler()
+
+	recorder := doRequest(t, handler, http.MethodGet, "/top?field=totalStakeNum&n=2", "")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	accounts := make([]*data.AccountInfoWithStakeValues, 0)
+	_ = json.Unmarshal(recorder.Body.Bytes(), &accounts)
+	require.Len(t, accounts, 2)
+	require.Equal(t, "erd1b", accounts[0].Address)
+	require.Equal(t, float64(20), accounts[0].TotalStakeNum)
+
+	recorder = doRequest(t, handler, http.MethodGet, "/top?field=balance&n=2", "")
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	recorder = doRequest(t, handler, http.MethodGet, "/top?field=totalStakeNum&n=11", "")
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestEpochFromIndex(t *testing.T) {
+	t.Parallel()
+
+	epoch, err := epochFromIndex("accounts-000001_1234")
+	require.Nil(t, err)
+	require.Equal(t, uint32(1234), epoch)
+
+	_, err = epochFromIndex("accounts-with-stake")
+	require.NotNil(t, err)
+}
@@ -1,11 +1,26 @@
 package main
 
 import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/multiversx/mx-chain-core-go/core"
 	logger "github.com/multiversx/mx-chain-logger-go"
 	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/configValidator"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex/pruner"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/elasticClient"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/metrics"
 	"github.com/multiversx/mx-chain-tools-accounts-manager-go/process"
 	"github.com/urfave/cli"
 )
@@ -34,6 +49,36 @@ var (
 			" log level.",
 		Value: "*:" + logger.LogInfo.String(),
 	}
+	// daemonMode is used when the manager should keep running and index the accounts once for every new epoch
+	daemonMode = cli.BoolFlag{
+		Name:  "daemon",
+		Usage: "Boolean option for enabling the daemon mode. If set, the manager will follow the epoch changes and will index the accounts once for every new epoch.",
+	}
+	// dryRun is used when the accounts should be processed without writing anything in the destination clusters
+	dryRun = cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "Boolean option for enabling the dry run mode. If set, the accounts will be fetched and merged, but nothing will be written in the destination clusters. A summary will be printed instead.",
+	}
+	// keepLast defines how many of the newest accounts indices are kept by the prune command
+	keepLast = cli.IntFlag{
+		Name:  "keep-last",
+		Usage: "The number of the newest accounts indices kept on every destination cluster. 0 disables the rule",
+	}
+	// keepEpochs defines the age, in epochs, of the accounts indices kept by the prune command
+	keepEpochs = cli.UintFlag{
+		Name:  "keep-epochs",
+		Usage: "The accounts indices newer than this number of epochs, counted from the newest index of a cluster, are kept. 0 disables the rule",
+	}
+	// pruneDryRun is used when the prune command should only list the accounts indices that would be deleted
+	pruneDryRun = cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "Boolean option for listing the accounts indices that would be deleted, without deleting them",
+	}
+	// assumeYes is used when the prune command should delete the indices without asking for a confirmation
+	assumeYes = cli.BoolFlag{
+		Name:  "yes",
+		Usage: "Boolean option for deleting the accounts indices without asking for a confirmation",
+	}
 	// logFile is used when the log output needs to be logged in a file
 	logSaveFile = cli.BoolFlag{
 		Name:  "log-save",
@@ -41,17 +86,28 @@ var (
 	}
 )
 
+// appVersion can be set at build time with -ldflags "-X main.appVersion=<version>"
+var appVersion = "v1.0.0"
+
+const (
+	queryAPIReadHeaderTimeout = 10 * time.Second
+	queryAPIShutdownTimeout   = 10 * time.Second
+	validateGatewayTimeout    = 10 * time.Second
+)
+
 func main() {
 	app := cli.NewApp()
 
 	app.Name = "Accounts Manager"
-	app.Version = "v1.0.0"
+	app.Version = appVersion
 	app.Usage = "This is the entry point for starting a new accounts manager"
 	app.Flags = []cli.Flag{
 		configurationFile,
 		logLevel,
 		logSaveFile,
 		indicesConfigPath,
+		daemonMode,
+		dryRun,
 	}
 	app.Authors = []cli.Author{
 		{
@@ -61,6 +117,29 @@ func main() {
 	}
 
 	app.Action = startAccountsManager
+	app.Commands = []cli.Command{
+		{
+			Name:   "serve",
+			Usage:  "Starts a read-only HTTP API over the latest accounts snapshot, on the address from the [QueryAPI] config section",
+			Action: startQueryAPI,
+		},
+		{
+			Name:   "prune",
+			Usage:  "Deletes the old accounts indices from the destination clusters, for clusters without index lifecycle management. The index behind the accounts alias is never deleted",
+			Action: pruneAccountsIndices,
+			Flags: []cli.Flag{
+				keepLast,
+				keepEpochs,
+				assumeYes,
+				pruneDryRun,
+			},
+		},
+		{
+			Name:   "validate-config",
+			Usage:  "Loads the config file and the indices folder and checks the addresses, the indices files, the Elasticsearch clusters and the gateways. Exits with a non-zero code if a check fails",
+			Action: validateConfig,
+		},
+	}
 
 	err := app.Run(os.Args)
 	if err != nil {
@@ -83,11 +162,35 @@ func startAccountsManager(ctx *cli.Context) error {
 		return err
 	}
 
-	dataProc, err := process.CreateDataProcessor(generalConfig, ctx.GlobalString(indicesConfigPath.Name))
+	if generalConfig.Destination.Export.Enabled && generalConfig.Destination.Export.OutputPath == "-" {
+		// the accounts are exported to stdout, so the logs are moved to stderr
+		err = redirectLogsToStderr()
+		if err != nil {
+			return err
+		}
+	}
+
+	dataProc, err := process.CreateDataProcessor(generalConfig, ctx.GlobalString(indicesConfigPath.Name), ctx.GlobalBool(dryRun.Name), appVersion)
 	if err != nil {
 		return err
 	}
 
+	if generalConfig.Metrics.ListenAddress != "" {
+		metricsServer, errStart := metrics.StartServer(generalConfig.Metrics.ListenAddress)
+		if errStart != nil {
+			return errStart
+		}
+		defer func() {
+			_ = metricsServer.Close()
+		}()
+	}
+
+	dataProc = newMetricsTextFileWriter(dataProc, generalConfig.Metrics.TextFilePath)
+
+	if ctx.GlobalBool(daemonMode.Name) {
+		return runDaemon(dataProc, generalConfig)
+	}
+
 	err = dataProc.ProcessAccountsData()
 	if err != nil {
 		return err
@@ -98,15 +201,242 @@ func startAccountsManager(ctx *cli.Context) error {
 	return nil
 }
 
+func startQueryAPI(ctx *cli.Context) error {
+	err := initializeLogger(ctx)
+	if err != nil {
+		return err
+	}
+
+	generalConfig, err := loadMainConfig(ctx.GlobalString(configurationFile.Name))
+	if err != nil {
+		return err
+	}
+
+	handler, err := process.CreateQueryAPIHandler(generalConfig)
+	if err != nil {
+		return err
+	}
+
+	server := &http.Server{
+		Addr:              generalConfig.QueryAPI.ListenAddress,
+		Handler:           handler,
+		ReadHeaderTimeout: queryAPIReadHeaderTimeout,
+	}
+
+	signalCtx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	go func() {
+		<-signalCtx.Done()
+
+		shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), queryAPIShutdownTimeout)
+		defer cancelShutdown()
+
+		errShutdown := server.Shutdown(shutdownCtx)
+		if errShutdown != nil {
+			log.Warn("cannot shut down the query API", "error", errShutdown)
+		}
+	}()
+
+	log.Info("starting the query API", "address", server.Addr)
+
+	err = server.ListenAndServe()
+	if errors.Is(err, http.ErrServerClosed) {
+		log.Info("query API stopped")
+		return nil
+	}
+
+	return err
+}
+
+func validateConfig(ctx *cli.Context) error {
+	err := initializeLogger(ctx)
+	if err != nil {
+		return err
+	}
+
+	configurationFileName := ctx.GlobalString(configurationFile.Name)
+	generalConfig, err := loadMainConfig(configurationFileName)
+	if err != nil {
+		return configValidator.PrintResults(os.Stdout, []*configValidator.CheckResult{
+			{Name: configurationFileName, Passed: false, Details: err.Error()},
+		})
+	}
+
+	validator, err := configValidator.NewConfigValidator(configValidator.ArgsConfigValidator{
+		Config:            generalConfig,
+		IndicesConfigPath: ctx.GlobalString(indicesConfigPath.Name),
+		NewElasticClient: func(cfg data.EsClientConfig) (configValidator.ElasticClusterChecker, error) {
+			return elasticClient.NewElasticClient(cfg)
+		},
+		HTTPClient: &http.Client{Timeout: validateGatewayTimeout},
+	})
+	if err != nil {
+		return err
+	}
+
+	return configValidator.PrintResults(os.Stdout, validator.Validate())
+}
+
+func pruneAccountsIndices(ctx *cli.Context) error {
+	err := initializeLogger(ctx)
+	if err != nil {
+		return err
+	}
+
+	generalConfig, err := loadMainConfig(ctx.GlobalString(configurationFile.Name))
+	if err != nil {
+		return err
+	}
+
+	accountsPruner, err := process.CreatePruner(generalConfig, ctx.Int(keepLast.Name), uint32(ctx.Uint(keepEpochs.Name)))
+	if err != nil {
+		return err
+	}
+
+	decisions, err := accountsPruner.Plan()
+	if err != nil {
+		return err
+	}
+
+	err = pruner.PrintPlan(os.Stdout, decisions)
+	if err != nil {
+		return err
+	}
+
+	numDeletions := pruner.CountDeletions(decisions)
+	if numDeletions == 0 {
+		log.Info("there is no accounts index to delete")
+		return nil
+	}
+	if ctx.Bool(pruneDryRun.Name) || ctx.GlobalBool(dryRun.Name) {
+		log.Info("dry run, no accounts index was deleted", "indices to delete", numDeletions)
+		return nil
+	}
+
+	if !ctx.Bool(assumeYes.Name) {
+		confirmed, errAsk := askConfirmation(os.Stdin, os.Stdout, fmt.Sprintf("Delete %d accounts indices? [y/N]: ", numDeletions))
+		if errAsk != nil {
+			return errAsk
+		}
+		if !confirmed {
+			log.Info("pruning aborted, no accounts index was deleted")
+			return nil
+		}
+	}
+
+	return accountsPruner.Prune(decisions)
+}
+
+func askConfirmation(reader io.Reader, writer io.Writer, question string) (bool, error) {
+	_, err := fmt.Fprint(writer, question)
+	if err != nil {
+		return false, err
+	}
+
+	answer, err := bufio.NewReader(reader).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, err
+	}
+
+	answer = strings.ToLower(strings.TrimSpace(answer))
+
+	return answer == "y" || answer == "yes", nil
+}
+
+func runDaemon(dataProc process.DataProcessor, cfg *config.Config) error {
+	daemon, err := process.NewEpochDaemon(dataProc, cfg.Daemon)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	return daemon.Run(ctx)
+}
+
+// metricsTextFileWriter writes the metrics in the textfile after every processed epoch, so the one-shot runs can be
+// collected by the node exporter
+type metricsTextFileWriter struct {
+	process.DataProcessor
+	textFilePath string
+}
+
+func newMetricsTextFileWriter(dataProc process.DataProcessor, textFilePath string) process.DataProcessor {
+	if textFilePath == "" {
+		return dataProc
+	}
+
+	return &metricsTextFileWriter{
+		DataProcessor: dataProc,
+		textFilePath:  textFilePath,
+	}
+}
+
+// ProcessAccountsData will process the accounts data for the current epoch and will write the metrics
+func (mw *metricsTextFileWriter) ProcessAccountsData() error {
+	err := mw.DataProcessor.ProcessAccountsData()
+	mw.writeTextFile()
+
+	return err
+}
+
+// ProcessAccountsDataForEpoch will process the accounts data for the provided epoch and will write the metrics
+func (mw *metricsTextFileWriter) ProcessAccountsDataForEpoch(epoch uint32) error {
+	err := mw.DataProcessor.ProcessAccountsDataForEpoch(epoch)
+	mw.writeTextFile()
+
+	return err
+}
+
+func (mw *metricsTextFileWriter) writeTextFile() {
+	err := metrics.WriteTextFile(mw.textFilePath)
+	if err != nil {
+		log.Warn("cannot write the metrics text file", "path", mw.textFilePath, "error", err)
+	}
+}
+
+// IsInterfaceNil returns true if the value under the interface is nil
+func (mw *metricsTextFileWriter) IsInterfaceNil() bool {
+	return mw == nil
+}
+
 func loadMainConfig(filepath string) (*config.Config, error) {
 	cfg := &config.Config{}
 	err := core.LoadTomlFile(cfg, filepath)
 	if err != nil {
 		return nil, err
 	}
+
+	err = config.ApplyEnvOverrides(cfg, os.LookupEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	err = config.ResolveSecretFiles(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	redactedConfig, err := config.RedactedJSON(cfg)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("loaded config", "config", redactedConfig)
+
 	return cfg, nil
 }
 
+func redirectLogsToStderr() error {
+	err := logger.RemoveLogObserver(os.Stdout)
+	if err != nil {
+		return err
+	}
+
+	return logger.AddLogObserver(os.Stderr, &logger.ConsoleFormatter{})
+}
+
 func initializeLogger(ctx *cli.Context) error {
 	logLevelFlagValue := ctx.GlobalString(logLevel.Name)
 	err := logger.SetLogLevel(logLevelFlagValue)
@@ -7,17 +7,29 @@ import (
 // Config will hold the whole config file's data
 type Config struct {
 	GeneralConfig          GeneralConfig
-	AddressPubkeyConverter struct {
-		Length int
-		Type   string
-	}
-	Reindexer struct {
+	AddressPubkeyConverter PubkeyConfig
+	Reindexer              struct {
 		SourceElasticSearchClient data.EsClientConfig
+		CheckpointFilePath        string
+		SourceReader              SourceReaderConfig
+		SanityGate                SanityGateConfig
 	}
 	Destination struct {
 		DestinationElasticSearchClients []data.EsClientConfig `toml:"DestinationElasticSearchClients"`
+		AccountsAlias                   string
+		Export                          ExportConfig
+		StakeHistory                    StakeHistoryConfig
+		WritePolicy                     WritePolicyConfig
+		BulkIndexer                     BulkIndexerConfig
+		LifecyclePolicy                 LifecyclePolicyConfig
 	}
-	APIConfig APIConfig
+	APIConfig     APIConfig
+	StakeSources  StakeSourcesConfig
+	SnapshotBlock SnapshotBlockConfig
+	Daemon        DaemonConfig
+	Metrics       MetricsConfig
+	QueryAPI      QueryAPIConfig
+	RunReport     RunReportConfig
 }
 
 // GeneralConfig will hold the general settings for an accounts manager
@@ -28,9 +40,115 @@ type GeneralConfig struct {
 	ValidatorsContract              string
 }
 
+// PubkeyConfig holds the configuration for the addresses converter
+type PubkeyConfig struct {
+	Length int
+	Type   string
+	Hrp    string
+}
+
 // APIConfig holds the configuration for the API
 type APIConfig struct {
-	URL      string
-	Username string
-	Password string
+	URL                          string
+	URLs                         []string
+	Username                     string
+	Password                     string
+	PasswordFile                 string
+	MaxAttempts                  int
+	RequestTimeoutInSeconds      int
+	OverallTimeoutInSeconds      int
+	InitialBackoffInMilliseconds int
+	MaxBackoffInSeconds          int
+	MaxGatewayFailures           int
+	GatewayCooldownInSeconds     int
+}
+
+// ExportConfig holds the configuration for the file based accounts export
+type ExportConfig struct {
+	Enabled    bool
+	Format     string
+	OutputPath string
+	Compress   bool
+}
+
+// StakeHistoryConfig holds the configuration for the per-account stake history index
+type StakeHistoryConfig struct {
+	Enabled bool
+}
+
+// SourceReaderConfig holds the configuration of the point in time used to read the source accounts index
+type SourceReaderConfig struct {
+	PageSize           int
+	KeepAliveInSeconds int
+	MaxRetries         int
+}
+
+// BulkIndexerConfig holds the configuration of the bulk requests used to write the documents in a destination cluster
+type BulkIndexerConfig struct {
+	NumWorkers                   int
+	FlushBytes                   int
+	FlushDocuments               int
+	MaxRetries                   int
+	InitialBackoffInMilliseconds int
+	MaxBackoffInSeconds          int
+}
+
+// LifecyclePolicyConfig holds the configuration of the lifecycle policies put on the destination clusters
+type LifecyclePolicyConfig struct {
+	Mode string
+}
+
+// WritePolicyConfig holds the configuration of how many destination clusters must be written for a run to succeed
+type WritePolicyConfig struct {
+	Mode   string
+	Quorum int
+}
+
+// DaemonConfig holds the configuration for the daemon mode
+type DaemonConfig struct {
+	PollIntervalInSeconds int
+	SettleDelayInSeconds  int
+}
+
+// StakeSourcesConfig holds the configuration for fetching the accounts from the stake sources
+type StakeSourcesConfig struct {
+	Enabled          []string
+	Optional         []string
+	TimeoutInSeconds int
+}
+
+// SnapshotBlockConfig holds the configuration of the block at which all the stake sources are read
+type SnapshotBlockConfig struct {
+	Mode  string
+	Nonce uint64
+}
+
+// MetricsConfig holds the configuration for exposing the Prometheus metrics
+type MetricsConfig struct {
+	ListenAddress string
+	TextFilePath  string
+}
+
+// SanityGateConfig holds the configuration of the checks done on a new accounts index before it is published
+type SanityGateConfig struct {
+	Enabled                    bool
+	Action                     string
+	MaxStakeDriftPercent       float64
+	MaxUnDelegatedDriftPercent float64
+	MaxEnergyDriftPercent      float64
+	MaxLKMEXDriftPercent       float64
+	MaxAccountsDriftPercent    float64
+}
+
+// QueryAPIConfig holds the configuration for the read-only HTTP API started by the serve subcommand
+type QueryAPIConfig struct {
+	ListenAddress string
+	Index         string
+	MaxBatchSize  int
+	MaxTopSize    int
+}
+
+// RunReportConfig holds the configuration for the report written at the end of every run
+type RunReportConfig struct {
+	FilePath string
 }
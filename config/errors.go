@@ -0,0 +1,9 @@
+package config
+
+import "errors"
+
+// ErrInvalidEnvOverride signals that an environment variable holds a value that cannot be set on its config field
+var ErrInvalidEnvOverride = errors.New("invalid environment variable override")
+
+// ErrSecretAndSecretFile signals that both a secret and the file holding that secret were provided
+var ErrSecretAndSecretFile = errors.New("both a secret and a secret file were provided")
@@ -0,0 +1,284 @@
+package configValidator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"text/tabwriter"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/core"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/restClient"
+)
+
+const (
+	pathNetworkStatusMeta = "/network/status/4294967295"
+
+	accountsTemplateFileName     = "accounts.json"
+	valuesTemplateFileName       = "values.json"
+	runsTemplateFileName         = "accounts-manager-runs.json"
+	accountsPolicyFileName       = "accounts-policy.json"
+	stakeHistoryTemplateFileName = "accounts-stake-history.json"
+	stakeHistoryPolicyFileName   = "accounts-stake-history-policy.json"
+)
+
+// CheckResult holds the result of a single config check
+type CheckResult struct {
+	Name    string
+	Passed  bool
+	Details string
+}
+
+// ArgsConfigValidator holds the arguments needed to create a new config validator
+type ArgsConfigValidator struct {
+	Config            *config.Config
+	IndicesConfigPath string
+	NewElasticClient  func(cfg data.EsClientConfig) (ElasticClusterChecker, error)
+	HTTPClient        *http.Client
+}
+
+type configValidator struct {
+	cfg               *config.Config
+	indicesConfigPath string
+	newElasticClient  func(cfg data.EsClientConfig) (ElasticClusterChecker, error)
+	httpClient        *http.Client
+}
+
+// NewConfigValidator will create a new instance of configValidator
+func NewConfigValidator(args ArgsConfigValidator) (*configValidator, error) {
+	if args.Config == nil {
+		return nil, ErrNilConfig
+	}
+	if args.NewElasticClient == nil {
+		return nil, ErrNilElasticClientCreator
+	}
+	if args.HTTPClient == nil {
+		return nil, ErrNilHTTPClient
+	}
+
+	return &configValidator{
+		cfg:               args.Config,
+		indicesConfigPath: args.IndicesConfigPath,
+		newElasticClient:  args.NewElasticClient,
+		httpClient:        args.HTTPClient,
+	}, nil
+}
+
+// Validate will run all the checks and will return their results, in order
+func (cv *configValidator) Validate() []*CheckResult {
+	results := make([]*CheckResult, 0)
+	results = append(results, cv.checkContractAddresses()...)
+	results = append(results, cv.checkIndicesConfig()...)
+	results = append(results, cv.checkElasticClusters()...)
+	results = append(results, cv.checkGateways()...)
+
+	return results
+}
+
+func (cv *configValidator) checkContractAddresses() []*CheckResult {
+	converter, err := core.NewPubkeyConverter(cv.cfg.AddressPubkeyConverter)
+	if err != nil {
+		return []*CheckResult{newResult("address converter", err)}
+	}
+
+	addresses := []struct {
+		name    string
+		address string
+	}{
+		{name: "DelegationLegacyContractAddress", address: cv.cfg.GeneralConfig.DelegationLegacyContractAddress},
+		{name: "LKMEXStakingContractAddress", address: cv.cfg.GeneralConfig.LKMEXStakingContractAddress},
+		{name: "EnergyContractAddress", address: cv.cfg.GeneralConfig.EnergyContractAddress},
+		{name: "ValidatorsContract", address: cv.cfg.GeneralConfig.ValidatorsContract},
+	}
+
+	results := make([]*CheckResult, 0, len(addresses))
+	for _, contract := range addresses {
+		name := "GeneralConfig." + contract.name
+		if contract.address == "" {
+			results = append(results, &CheckResult{Name: name, Passed: true, Details: "not set, skipped"})
+			continue
+		}
+
+		_, err = converter.Decode(contract.address)
+		results = append(results, newResult(name, err))
+	}
+
+	return results
+}
+
+func (cv *configValidator) checkIndicesConfig() []*CheckResult {
+	results := []*CheckResult{
+		newResult(accountsTemplateFileName, checkTemplateFile(path.Join(cv.indicesConfigPath, accountsTemplateFileName))),
+		newResult(valuesTemplateFileName, checkTemplateFile(path.Join(cv.indicesConfigPath, valuesTemplateFileName))),
+		newResult(runsTemplateFileName, checkTemplateFile(path.Join(cv.indicesConfigPath, runsTemplateFileName))),
+		newResult(accountsPolicyFileName, checkPolicyFile(path.Join(cv.indicesConfigPath, accountsPolicyFileName))),
+	}
+	if cv.cfg.Destination.StakeHistory.Enabled {
+		results = append(results,
+			newResult(stakeHistoryTemplateFileName, checkTemplateFile(path.Join(cv.indicesConfigPath, stakeHistoryTemplateFileName))),
+			newResult(stakeHistoryPolicyFileName, checkPolicyFile(path.Join(cv.indicesConfigPath, stakeHistoryPolicyFileName))),
+		)
+	}
+
+	return results
+}
+
+func (cv *configValidator) checkElasticClusters() []*CheckResult {
+	results := []*CheckResult{
+		cv.checkElasticCluster("source cluster", cv.cfg.Reindexer.SourceElasticSearchClient),
+	}
+	for idx, esConfig := range cv.cfg.Destination.DestinationElasticSearchClients {
+		results = append(results, cv.checkElasticCluster(fmt.Sprintf("destination cluster %d", idx), esConfig))
+	}
+
+	return results
+}
+
+func (cv *configValidator) checkElasticCluster(name string, esConfig data.EsClientConfig) *CheckResult {
+	name = fmt.Sprintf("%s %s", name, esConfig.Address)
+
+	esClient, err := cv.newElasticClient(esConfig)
+	if err != nil {
+		return newResult(name, err)
+	}
+
+	return newResult(name, esClient.CheckConnection())
+}
+
+func (cv *configValidator) checkGateways() []*CheckResult {
+	urls := restClient.GetGatewaysURLs(cv.cfg.APIConfig)
+	if len(urls) == 0 {
+		return []*CheckResult{newResult("gateway", restClient.ErrNoGatewayURL)}
+	}
+
+	results := make([]*CheckResult, 0, len(urls))
+	for _, url := range urls {
+		results = append(results, newResult("gateway "+url, cv.checkGateway(url)))
+	}
+
+	return results
+}
+
+func (cv *configValidator) checkGateway(url string) error {
+	req, err := http.NewRequest(http.MethodGet, url+pathNetworkStatusMeta, nil)
+	if err != nil {
+		return err
+	}
+
+	authenticationData := core.FetchAuthenticationData(cv.cfg.APIConfig)
+	if core.ShouldUseBasicAuthentication(authenticationData) {
+		req.SetBasicAuth(authenticationData.Username, authenticationData.Password)
+	}
+
+	resp, err := cv.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	response := &data.GenericAPIResponse{}
+	err = json.NewDecoder(resp.Body).Decode(response)
+	if err != nil {
+		return fmt.Errorf("invalid response: %w", err)
+	}
+	if response.Error != "" {
+		return fmt.Errorf("%s", response.Error)
+	}
+
+	return nil
+}
+
+func checkTemplateFile(filePath string) error {
+	template := struct {
+		Mappings map[string]interface{} `json:"mappings"`
+	}{}
+	err := readJSONFile(filePath, &template)
+	if err != nil {
+		return err
+	}
+	if len(template.Mappings) == 0 {
+		return fmt.Errorf("no mappings in %s", filePath)
+	}
+
+	return nil
+}
+
+func checkPolicyFile(filePath string) error {
+	policy := struct {
+		Policy map[string]interface{} `json:"policy"`
+	}{}
+	err := readJSONFile(filePath, &policy)
+	if err != nil {
+		return err
+	}
+	if len(policy.Policy) == 0 {
+		return fmt.Errorf("no policy in %s", filePath)
+	}
+
+	return nil
+}
+
+func readJSONFile(filePath string, value interface{}) error {
+	fileBytes, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(fileBytes, value)
+	if err != nil {
+		return fmt.Errorf("invalid JSON in %s: %w", filePath, err)
+	}
+
+	return nil
+}
+
+func newResult(name string, err error) *CheckResult {
+	if err != nil {
+		return &CheckResult{Name: name, Passed: false, Details: err.Error()}
+	}
+
+	return &CheckResult{Name: name, Passed: true}
+}
+
+// PrintResults will print the results as a table and will return ErrValidationFailed if at least one check failed
+func PrintResults(w io.Writer, results []*CheckResult) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "CHECK\tRESULT\tDETAILS")
+
+	numFailed := 0
+	for _, result := range results {
+		status := "PASS"
+		if !result.Passed {
+			status = "FAIL"
+			numFailed++
+		}
+
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\n", result.Name, status, result.Details)
+	}
+
+	err := tw.Flush()
+	if err != nil {
+		return err
+	}
+
+	if numFailed > 0 {
+		return fmt.Errorf("%w: %d of %d checks failed", ErrValidationFailed, numFailed, len(results))
+	}
+
+	return nil
+}
+
+// IsInterfaceNil returns true if the value under the interface is nil
+func (cv *configValidator) IsInterfaceNil() bool {
+	return cv == nil
+}
@@ -0,0 +1,155 @@
+package configValidator
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	pathToIndicesConfig      = "../cmd/manager/config/indices"
+	delegationLegacyContract = "erd1qqqqqqqqqqqqqpgqxwakt2g7u9atsnr03gqcgmhcv38pt7mkd94q6shuwt"
+)
+
+func createConfig(gatewayURL string) *config.Config {
+	cfg := &config.Config{}
+	cfg.GeneralConfig.DelegationLegacyContractAddress = delegationLegacyContract
+	cfg.AddressPubkeyConverter = config.PubkeyConfig{Length: 32, Type: "bech32", Hrp: "erd"}
+	cfg.Reindexer.SourceElasticSearchClient = data.EsClientConfig{Address: "http://source:9200"}
+	cfg.Destination.DestinationElasticSearchClients = []data.EsClientConfig{{Address: "http://destination:9200"}}
+	cfg.APIConfig.URL = gatewayURL
+
+	return cfg
+}
+
+func createArgs(cfg *config.Config) ArgsConfigValidator {
+	return ArgsConfigValidator{
+		Config:            cfg,
+		IndicesConfigPath: pathToIndicesConfig,
+		NewElasticClient: func(esConfig data.EsClientConfig) (ElasticClusterChecker, error) {
+			return &mocks.ElasticClusterCheckerStub{}, nil
+		},
+		HTTPClient: http.DefaultClient,
+	}
+}
+
+func createGateway(t *testing.T, status int, body string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, pathNetworkStatusMeta, r.URL.Path)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func requireResult(t *testing.T, results []*CheckResult, name string, passed bool) *CheckResult {
+	for _, result := range results {
+		if result.Name == name {
+			require.Equal(t, passed, result.Passed, "check %s: %s", name, result.Details)
+			return result
+		}
+	}
+
+	require.Fail(t, "check not found", name)
+	return nil
+}
+
+func TestNewConfigValidator(t *testing.T) {
+	t.Parallel()
+
+	args := createArgs(nil)
+	_, err := NewConfigValidator(args)
+	require.Equal(t, ErrNilConfig, err)
+
+	args = createArgs(createConfig(""))
+	args.NewElasticClient = nil
+	_, err = NewConfigValidator(args)
+	require.Equal(t, ErrNilElasticClientCreator, err)
+
+	args = createArgs(createConfig(""))
+	args.HTTPClient = nil
+	_, err = NewConfigValidator(args)
+	require.Equal(t, ErrNilHTTPClient, err)
+
+	cv, err := NewConfigValidator(createArgs(createConfig("")))
+	require.Nil(t, err)
+	require.False(t, cv.IsInterfaceNil())
+}
+
+func TestConfigValidator_ValidateShouldPass(t *testing.T) {
+	t.Parallel()
+
+	gateway := createGateway(t, http.StatusOK, `{"data":{"status":{"erd_epoch_number":10}},"error":"","code":"successful"}`)
+	cv, _ := NewConfigValidator(createArgs(createConfig(gateway.URL)))
+
+	results := cv.Validate()
+	for _, result := range results {
+		require.True(t, result.Passed, "check %s: %s", result.Name, result.Details)
+	}
+	requireResult(t, results, "GeneralConfig.EnergyContractAddress", true)
+	requireResult(t, results, "accounts.json", true)
+	requireResult(t, results, "accounts-manager-runs.json", true)
+	requireResult(t, results, "source cluster http://source:9200", true)
+	requireResult(t, results, "gateway "+gateway.URL, true)
+
+	buff := &bytes.Buffer{}
+	err := PrintResults(buff, results)
+	require.Nil(t, err)
+	require.True(t, strings.HasPrefix(buff.String(), "CHECK"))
+}
+
+func TestConfigValidator_ValidateShouldReportFailures(t *testing.T) {
+	t.Parallel()
+
+	gateway := createGateway(t, http.StatusInternalServerError, `{"error":"internal error"}`)
+	cfg := createConfig(gateway.URL)
+	cfg.GeneralConfig.EnergyContractAddress = "erd1invalid"
+
+	indicesPath := t.TempDir()
+	_ = ioutil.WriteFile(filepath.Join(indicesPath, accountsTemplateFileName), []byte(`{"settings":{}}`), 0644)
+	_ = ioutil.WriteFile(filepath.Join(indicesPath, valuesTemplateFileName), []byte(`{"mappings":`), 0644)
+	_ = ioutil.WriteFile(filepath.Join(indicesPath, accountsPolicyFileName), []byte(`{"policy":{"phases":{}}}`), 0644)
+
+	args := createArgs(cfg)
+	args.IndicesConfigPath = indicesPath
+	args.NewElasticClient = func(esConfig data.EsClientConfig) (ElasticClusterChecker, error) {
+		return &mocks.ElasticClusterCheckerStub{
+			CheckConnectionCalled: func() error {
+				if esConfig.Address == "http://destination:9200" {
+					return errors.New("401 Unauthorized")
+				}
+				return nil
+			},
+		}, nil
+	}
+	cv, _ := NewConfigValidator(args)
+
+	results := cv.Validate()
+	requireResult(t, results, "GeneralConfig.DelegationLegacyContractAddress", true)
+	requireResult(t, results, "GeneralConfig.EnergyContractAddress", false)
+	requireResult(t, results, "accounts.json", false)
+	requireResult(t, results, "values.json", false)
+	requireResult(t, results, "accounts-manager-runs.json", false)
+	requireResult(t, results, "accounts-policy.json", true)
+	requireResult(t, results, "source cluster http://source:9200", true)
+	result := requireResult(t, results, "destination cluster 0 http://destination:9200", false)
+	require.Equal(t, "401 Unauthorized", result.Details)
+	requireResult(t, results, "gateway "+gateway.URL, false)
+
+	buff := &bytes.Buffer{}
+	err := PrintResults(buff, results)
+	require.ErrorIs(t, err, ErrValidationFailed)
+	require.True(t, strings.Contains(buff.String(), "FAIL"))
+}
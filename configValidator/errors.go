@@ -0,0 +1,15 @@
+package configValidator
+
+import "errors"
+
+// ErrNilConfig signals that a nil config has been provided
+var ErrNilConfig = errors.New("nil config")
+
+// ErrNilElasticClientCreator signals that a nil elastic client creator function has been provided
+var ErrNilElasticClientCreator = errors.New("nil elastic client creator")
+
+// ErrNilHTTPClient signals that a nil http client has been provided
+var ErrNilHTTPClient = errors.New("nil http client")
+
+// ErrValidationFailed signals that at least one of the config checks failed
+var ErrValidationFailed = errors.New("config validation failed")
@@ -0,0 +1,7 @@
+package configValidator
+
+// ElasticClusterChecker defines what an elastic client should be able to do for the config validation
+type ElasticClusterChecker interface {
+	CheckConnection() error
+	IsInterfaceNil() bool
+}
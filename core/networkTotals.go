@@ -0,0 +1,64 @@
+package core
+
+import (
+	"math/big"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+)
+
+// networkTotalsFields holds the stake fields summed in the network totals, by the name of their total. The names are
+// the ones of the fields from the accounts index
+var networkTotalsFields = map[string]func(stakeInfo *data.StakeInfo) string{
+	"validatorsActive":        func(si *data.StakeInfo) string { return si.ValidatorsActive },
+	"validatorsTopUp":         func(si *data.StakeInfo) string { return si.ValidatorTopUp },
+	"delegation":              func(si *data.StakeInfo) string { return si.Delegation },
+	"delegationLegacyWaiting": func(si *data.StakeInfo) string { return si.DelegationLegacyWaiting },
+	"delegationLegacyActive":  func(si *data.StakeInfo) string { return si.DelegationLegacyActive },
+	"unDelegateLegacy":        func(si *data.StakeInfo) string { return si.UnDelegateLegacy },
+	"unDelegateValidator":     func(si *data.StakeInfo) string { return si.UnDelegateValidator },
+	"unDelegateDelegation":    func(si *data.StakeInfo) string { return si.UnDelegateDelegation },
+	"totalUnDelegate":         func(si *data.StakeInfo) string { return si.TotalUnDelegate },
+	"totalStake":              func(si *data.StakeInfo) string { return si.TotalStake },
+	"lkMexStake":              func(si *data.StakeInfo) string { return si.LKMEXStake },
+	"energy":                  func(si *data.StakeInfo) string { return si.Energy },
+}
+
+// ComputeNetworkTotals will sum the stake values of all the accounts with stake and will count the accounts of every
+// stake source
+func ComputeNetworkTotals(accountsData *data.AccountsData) *data.NetworkTotals {
+	sums := make(map[string]*big.Int, len(networkTotalsFields))
+	for name := range networkTotalsFields {
+		sums[name] = big.NewInt(0)
+	}
+
+	for _, account := range accountsData.AccountsWithStake {
+		for name, getValue := range networkTotalsFields {
+			value, ok := big.NewInt(0).SetString(getValue(&account.StakeInfo), 10)
+			if !ok {
+				continue
+			}
+
+			sums[name].Add(sums[name], value)
+		}
+	}
+
+	totals := make(map[string]*data.TotalValue, len(sums))
+	for name, sum := range sums {
+		totals[name] = &data.TotalValue{
+			Value:    sum.String(),
+			ValueNum: ComputeBalanceAsFloat(sum.String()),
+		}
+	}
+
+	sourceAccounts := make(map[string]int, len(accountsData.Sources))
+	for _, source := range accountsData.Sources {
+		sourceAccounts[source.Name] = source.NumAccounts
+	}
+
+	return &data.NetworkTotals{
+		Epoch:                accountsData.Epoch,
+		Totals:               totals,
+		NumAccountsWithStake: len(accountsData.AccountsWithStake),
+		SourceAccounts:       sourceAccounts,
+	}
+}
@@ -0,0 +1,51 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeNetworkTotals(t *testing.T) {
+	t.Parallel()
+
+	accountsData := &data.AccountsData{
+		AccountsWithStake: map[string]*data.AccountInfoWithStakeValues{
+			"erd1a": {StakeInfo: data.StakeInfo{
+				ValidatorsActive:        "2500000000000000000000",
+				ValidatorTopUp:          "1000000000000000000",
+				DelegationLegacyWaiting: "5000000000000000000",
+				UnDelegateLegacy:        "1000000000000000000",
+				TotalStake:              "2501000000000000000000",
+			}},
+			"erd1b": {StakeInfo: data.StakeInfo{
+				Delegation:             "10000000000000000000",
+				DelegationLegacyActive: "20000000000000000000",
+				UnDelegateDelegation:   "3000000000000000000",
+				TotalStake:             "30000000000000000000",
+				Energy:                 "123",
+			}},
+			"erd1c": {StakeInfo: data.StakeInfo{
+				LKMEXStake: "not a number",
+			}},
+		},
+		Epoch:   7,
+		Sources: []data.SourceInfo{{Name: "validators", NumAccounts: 1}, {Name: "delegators", NumAccounts: 2}},
+	}
+
+	totals := ComputeNetworkTotals(accountsData)
+	require.Equal(t, uint32(7), totals.Epoch)
+	require.Equal(t, 3, totals.NumAccountsWithStake)
+	require.Equal(t, map[string]int{"validators": 1, "delegators": 2}, totals.SourceAccounts)
+	require.Len(t, totals.Totals, len(networkTotalsFields))
+
+	require.Equal(t, &data.TotalValue{Value: "2500000000000000000000", ValueNum: 2500}, totals.Totals["validatorsActive"])
+	require.Equal(t, &data.TotalValue{Value: "2531000000000000000000", ValueNum: 2531}, totals.Totals["totalStake"])
+	require.Equal(t, &data.TotalValue{Value: "5000000000000000000", ValueNum: 5}, totals.Totals["delegationLegacyWaiting"])
+	require.Equal(t, &data.TotalValue{Value: "20000000000000000000", ValueNum: 20}, totals.Totals["delegationLegacyActive"])
+	require.Equal(t, &data.TotalValue{Value: "1000000000000000000", ValueNum: 1}, totals.Totals["unDelegateLegacy"])
+	require.Equal(t, "3000000000000000000", totals.Totals["unDelegateDelegation"].Value)
+	require.Equal(t, "123", totals.Totals["energy"].Value)
+	require.Equal(t, &data.TotalValue{Value: "0", ValueNum: 0}, totals.Totals["lkMexStake"])
+}
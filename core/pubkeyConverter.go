@@ -0,0 +1,123 @@
+package core
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcutil/bech32"
+	nodeCore "github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/pubkeyConverter"
+	logger "github.com/multiversx/mx-chain-logger-go"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+)
+
+var log = logger.GetOrCreate("core")
+
+const (
+	// HexPubkeyConverterType is the type of the converter that encodes the addresses as hex strings
+	HexPubkeyConverterType = "hex"
+	// Bech32PubkeyConverterType is the type of the converter that encodes the addresses as bech32 strings
+	Bech32PubkeyConverterType = "bech32"
+
+	defaultBech32Hrp = "erd"
+	bech32FromBits   = byte(8)
+	bech32ToBits     = byte(5)
+)
+
+// ErrUnknownPubkeyConverterType signals that an unknown public key converter type has been provided
+var ErrUnknownPubkeyConverterType = errors.New("unknown public key converter type")
+
+// ErrInvalidBech32Prefix signals that an address with a different bech32 prefix has been provided
+var ErrInvalidBech32Prefix = errors.New("invalid bech32 prefix")
+
+// NewPubkeyConverter will create a hex or a bech32 public key converter, according to the provided config
+func NewPubkeyConverter(cfg config.PubkeyConfig) (nodeCore.PubkeyConverter, error) {
+	switch cfg.Type {
+	case HexPubkeyConverterType:
+		return pubkeyConverter.NewHexPubkeyConverter(cfg.Length)
+	case Bech32PubkeyConverterType:
+		hrp := cfg.Hrp
+		if hrp == "" {
+			hrp = defaultBech32Hrp
+		}
+
+		return NewBech32PubkeyConverter(cfg.Length, hrp)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownPubkeyConverterType, cfg.Type)
+	}
+}
+
+type bech32PubkeyConverter struct {
+	len int
+	hrp string
+}
+
+// NewBech32PubkeyConverter returns a bech32 public key converter that uses the provided human-readable prefix
+func NewBech32PubkeyConverter(addressLen int, hrp string) (*bech32PubkeyConverter, error) {
+	if addressLen < 1 || addressLen%2 == 1 {
+		return nil, fmt.Errorf("%w: %d", pubkeyConverter.ErrInvalidAddressLength, addressLen)
+	}
+	if hrp == "" {
+		return nil, fmt.Errorf("%w: empty prefix", ErrInvalidBech32Prefix)
+	}
+
+	return &bech32PubkeyConverter{
+		len: addressLen,
+		hrp: hrp,
+	}, nil
+}
+
+// Len returns the decoded address length
+func (bpc *bech32PubkeyConverter) Len() int {
+	return bpc.len
+}
+
+// Decode converts the provided bech32 address in bytes
+func (bpc *bech32PubkeyConverter) Decode(humanReadable string) ([]byte, error) {
+	decodedPrefix, buff, err := bech32.Decode(humanReadable)
+	if err != nil {
+		return nil, err
+	}
+	if decodedPrefix != bpc.hrp {
+		return nil, fmt.Errorf("%w: expected %s, received %s", ErrInvalidBech32Prefix, bpc.hrp, decodedPrefix)
+	}
+
+	decodedBytes, err := bech32.ConvertBits(buff, bech32ToBits, bech32FromBits, false)
+	if err != nil {
+		return nil, pubkeyConverter.ErrBech32ConvertError
+	}
+	if len(decodedBytes) != bpc.len {
+		return nil, fmt.Errorf("%w when decoding address, expected length %d, received %d",
+			pubkeyConverter.ErrWrongSize, bpc.len, len(decodedBytes))
+	}
+
+	return decodedBytes, nil
+}
+
+// Encode converts the provided bytes in a bech32 address. It returns an empty string if the bytes cannot be encoded
+func (bpc *bech32PubkeyConverter) Encode(pkBytes []byte) string {
+	if len(pkBytes) != bpc.len {
+		log.Debug("bech32PubkeyConverter.Encode", "hex buff", hex.EncodeToString(pkBytes), "error", pubkeyConverter.ErrWrongSize)
+		return ""
+	}
+
+	conv, err := bech32.ConvertBits(pkBytes, bech32FromBits, bech32ToBits, true)
+	if err != nil {
+		log.Warn("bech32PubkeyConverter.Encode ConvertBits", "hex buff", hex.EncodeToString(pkBytes), "error", err)
+		return ""
+	}
+
+	converted, err := bech32.Encode(bpc.hrp, conv)
+	if err != nil {
+		log.Warn("bech32PubkeyConverter.Encode", "hex buff", hex.EncodeToString(pkBytes), "error", err)
+		return ""
+	}
+
+	return converted
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (bpc *bech32PubkeyConverter) IsInterfaceNil() bool {
+	return bpc == nil
+}
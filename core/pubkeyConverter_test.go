@@ -0,0 +1,60 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core/pubkeyConverter"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPubkeyConverter(t *testing.T) {
+	t.Parallel()
+
+	converter, err := NewPubkeyConverter(config.PubkeyConfig{Length: 32, Type: "unknown"})
+	require.Nil(t, converter)
+	require.True(t, errors.Is(err, ErrUnknownPubkeyConverterType))
+
+	address := make([]byte, 32)
+	address[31] = 1
+
+	converter, err = NewPubkeyConverter(config.PubkeyConfig{Length: 32, Type: HexPubkeyConverterType})
+	require.Nil(t, err)
+	require.Equal(t, "0000000000000000000000000000000000000000000000000000000000000001", converter.Encode(address))
+
+	// the default prefix matches the protocol's converter
+	protocolConverter, _ := pubkeyConverter.NewBech32PubkeyConverter(32, log)
+	converter, err = NewPubkeyConverter(config.PubkeyConfig{Length: 32, Type: Bech32PubkeyConverterType})
+	require.Nil(t, err)
+	require.Equal(t, protocolConverter.Encode(address), converter.Encode(address))
+}
+
+func TestBech32PubkeyConverter_CustomPrefix(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewBech32PubkeyConverter(32, "")
+	require.True(t, errors.Is(err, ErrInvalidBech32Prefix))
+	_, err = NewBech32PubkeyConverter(31, "test")
+	require.True(t, errors.Is(err, pubkeyConverter.ErrInvalidAddressLength))
+
+	converter, err := NewBech32PubkeyConverter(32, "test")
+	require.Nil(t, err)
+	require.Equal(t, 32, converter.Len())
+
+	address := make([]byte, 32)
+	address[0], address[31] = 7, 9
+
+	encoded := converter.Encode(address)
+	require.Regexp(t, "^test1", encoded)
+
+	decoded, err := converter.Decode(encoded)
+	require.Nil(t, err)
+	require.Equal(t, address, decoded)
+
+	erdConverter, _ := NewBech32PubkeyConverter(32, defaultBech32Hrp)
+	_, err = erdConverter.Decode(encoded)
+	require.True(t, errors.Is(err, ErrInvalidBech32Prefix))
+
+	require.Empty(t, converter.Encode([]byte{1}))
+}
@@ -0,0 +1,57 @@
+package core
+
+import (
+	"math"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+)
+
+// ComputeShardID will compute the shard of the provided address in a network with the provided number of shards,
+// the same way the protocol's shard coordinator does
+func ComputeShardID(address []byte, numShards uint32) uint32 {
+	if isSmartContractOnMetachain(address) {
+		return core.MetachainShardId
+	}
+	if numShards <= 1 {
+		return 0
+	}
+
+	bytesNeeded := 1
+	if numShards > math.MaxUint8+1 {
+		bytesNeeded = 2
+	}
+	if numShards > math.MaxUint16+1 {
+		bytesNeeded = 3
+	}
+
+	startingIndex := 0
+	if len(address) > bytesNeeded {
+		startingIndex = len(address) - bytesNeeded
+	}
+
+	addr := uint32(0)
+	for _, b := range address[startingIndex:] {
+		addr = addr<<8 + uint32(b)
+	}
+
+	maskHigh, maskLow := computeShardMasks(numShards)
+	shard := addr & maskHigh
+	if shard > numShards-1 {
+		shard = addr & maskLow
+	}
+
+	return shard
+}
+
+func isSmartContractOnMetachain(address []byte) bool {
+	if len(address) == 0 {
+		return false
+	}
+
+	return core.IsSmartContractOnMetachain(address[len(address)-1:], address)
+}
+
+func computeShardMasks(numShards uint32) (uint32, uint32) {
+	n := math.Ceil(math.Log2(float64(numShards)))
+	return (1 << uint(n)) - 1, (1 << uint(n-1)) - 1
+}
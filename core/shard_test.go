@@ -0,0 +1,31 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/pubkeyConverter"
+	logger "github.com/multiversx/mx-chain-logger-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeShardID(t *testing.T) {
+	t.Parallel()
+
+	converter, _ := pubkeyConverter.NewBech32PubkeyConverter(32, logger.GetOrCreate("core"))
+	decode := func(address string) []byte {
+		decoded, err := converter.Decode(address)
+		require.Nil(t, err)
+		return decoded
+	}
+
+	// system smart contracts live on metachain
+	require.Equal(t, core.MetachainShardId, ComputeShardID(decode("erd1qqqqqqqqqqqqqqqpqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqhllllsajxzat"), 3))
+
+	require.Equal(t, uint32(0), ComputeShardID([]byte{0, 0, 0, 0}, 3))
+	require.Equal(t, uint32(1), ComputeShardID([]byte{0, 0, 0, 1}, 3))
+	require.Equal(t, uint32(2), ComputeShardID([]byte{0, 0, 0, 2}, 3))
+	// the last two bits are 11, so the low mask is applied
+	require.Equal(t, uint32(1), ComputeShardID([]byte{0, 0, 0, 3}, 3))
+	require.Equal(t, uint32(0), ComputeShardID([]byte{0, 0, 0, 3}, 1))
+}
@@ -7,6 +7,12 @@ import (
 // AccountsPolicyName is the name of the policy for the accounts index
 const AccountsPolicyName = "accounts-manager-retention-policy"
 
+// StakeHistoryPolicyName is the name of the policy for the stake history indices
+const StakeHistoryPolicyName = "accounts-stake-history-retention-policy"
+
+// StakeHistoryAlias is the write alias of the stake history indices
+const StakeHistoryAlias = "accounts-stake-history"
+
 // AllAccountsResponse is a structure that matches the response format for an all accounts request
 type AllAccountsResponse struct {
 	ScrollID string `json:"_scroll_id"`
@@ -0,0 +1,57 @@
+package exporter
+
+import (
+	"strconv"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+)
+
+type csvColumn struct {
+	name  string
+	value func(account *data.AccountInfoWithStakeValues) string
+}
+
+var csvColumns = []csvColumn{
+	{"balance", func(a *data.AccountInfoWithStakeValues) string { return a.Balance }},
+	{"balanceNum", func(a *data.AccountInfoWithStakeValues) string { return formatFloat(a.BalanceNum) }},
+	{"totalBalanceWithStake", func(a *data.AccountInfoWithStakeValues) string { return a.TotalBalanceWithStake }},
+	{"totalBalanceWithStakeNum", func(a *data.AccountInfoWithStakeValues) string { return formatFloat(a.TotalBalanceWithStakeNum) }},
+	{"delegationLegacyWaiting", func(a *data.AccountInfoWithStakeValues) string { return a.DelegationLegacyWaiting }},
+	{"delegationLegacyActive", func(a *data.AccountInfoWithStakeValues) string { return a.DelegationLegacyActive }},
+	{"validatorsActive", func(a *data.AccountInfoWithStakeValues) string { return a.ValidatorsActive }},
+	{"validatorsTopUp", func(a *data.AccountInfoWithStakeValues) string { return a.ValidatorTopUp }},
+	{"delegation", func(a *data.AccountInfoWithStakeValues) string { return a.Delegation }},
+	{"totalStake", func(a *data.AccountInfoWithStakeValues) string { return a.TotalStake }},
+	{"totalStakeNum", func(a *data.AccountInfoWithStakeValues) string { return formatFloat(a.TotalStakeNum) }},
+	{"lkMexStake", func(a *data.AccountInfoWithStakeValues) string { return a.LKMEXStake }},
+	{"energy", func(a *data.AccountInfoWithStakeValues) string { return a.Energy }},
+	{"unDelegateLegacy", func(a *data.AccountInfoWithStakeValues) string { return a.UnDelegateLegacy }},
+	{"unDelegateValidator", func(a *data.AccountInfoWithStakeValues) string { return a.UnDelegateValidator }},
+	{"unDelegateDelegation", func(a *data.AccountInfoWithStakeValues) string { return a.UnDelegateDelegation }},
+	{"totalUnDelegate", func(a *data.AccountInfoWithStakeValues) string { return a.TotalUnDelegate }},
+	{"totalUnDelegateNum", func(a *data.AccountInfoWithStakeValues) string { return formatFloat(a.TotalUnDelegateNum) }},
+}
+
+func csvHeader() []string {
+	header := make([]string, 0, len(csvColumns)+1)
+	header = append(header, "address")
+	for _, column := range csvColumns {
+		header = append(header, column.name)
+	}
+
+	return header
+}
+
+func csvRow(address string, account *data.AccountInfoWithStakeValues) []string {
+	row := make([]string, 0, len(csvColumns)+1)
+	row = append(row, address)
+	for _, column := range csvColumns {
+		row = append(row, column.value(account))
+	}
+
+	return row
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
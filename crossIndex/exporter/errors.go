@@ -0,0 +1,15 @@
+package exporter
+
+import "errors"
+
+// ErrInvalidExportFormat signals that an invalid export format has been provided
+var ErrInvalidExportFormat = errors.New("invalid export format")
+
+// ErrEmptyOutputPath signals that an empty output path has been provided
+var ErrEmptyOutputPath = errors.New("empty output path")
+
+// ErrNilWriter signals that a nil writer has been provided
+var ErrNilWriter = errors.New("nil writer")
+
+// ErrExportNotStarted signals that the export was not started
+var ErrExportNotStarted = errors.New("export not started")
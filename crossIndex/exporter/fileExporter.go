@@ -0,0 +1,291 @@
+package exporter
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	logger "github.com/multiversx/mx-chain-logger-go"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+)
+
+const (
+	// FormatNDJSON will export every account as a JSON document on a separate line
+	FormatNDJSON = "ndjson"
+	// FormatCSV will export every account as a CSV row
+	FormatCSV = "csv"
+
+	stdoutOutputPath = "-"
+	filesPrefix      = "accounts"
+	gzipExtension    = ".gz"
+	tmpExtension     = ".tmp"
+)
+
+var log = logger.GetOrCreate("exporter")
+
+type fileExporter struct {
+	format     string
+	outputPath string
+	compress   bool
+	stdout     io.Writer
+
+	epoch        uint32
+	numRows      uint64
+	fileName     string
+	file         *os.File
+	gzipWriter   *gzip.Writer
+	bufferWriter *bufio.Writer
+	csvWriter    *csv.Writer
+}
+
+// NewFileExporter returns a new instance of an exporter that writes the accounts in a file per epoch or to stdout
+func NewFileExporter(exportConfig config.ExportConfig, stdout io.Writer) (*fileExporter, error) {
+	if exportConfig.Format != FormatNDJSON && exportConfig.Format != FormatCSV {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidExportFormat, exportConfig.Format)
+	}
+	if exportConfig.OutputPath == "" {
+		return nil, ErrEmptyOutputPath
+	}
+	if stdout == nil {
+		return nil, ErrNilWriter
+	}
+
+	return &fileExporter{
+		format:     exportConfig.Format,
+		outputPath: exportConfig.OutputPath,
+		compress:   exportConfig.Compress,
+		stdout:     stdout,
+	}, nil
+}
+
+// Start will prepare the export of the accounts for the provided epoch
+func (fe *fileExporter) Start(epoch uint32) error {
+	fe.Abort()
+
+	fe.epoch = epoch
+	fe.numRows = 0
+
+	var writer io.Writer = fe.stdout
+	if !fe.isStdout() {
+		err := os.MkdirAll(fe.outputPath, os.ModePerm)
+		if err != nil {
+			return err
+		}
+
+		fe.fileName = fe.computeFileName(epoch)
+		fe.file, err = os.Create(filepath.Join(fe.outputPath, fe.fileName+tmpExtension))
+		if err != nil {
+			return err
+		}
+
+		writer = fe.file
+		if fe.compress {
+			fe.gzipWriter = gzip.NewWriter(fe.file)
+			writer = fe.gzipWriter
+		}
+	}
+
+	fe.bufferWriter = bufio.NewWriter(writer)
+	if fe.format == FormatCSV {
+		fe.csvWriter = csv.NewWriter(fe.bufferWriter)
+		return fe.csvWriter.Write(csvHeader())
+	}
+
+	return nil
+}
+
+// Export will write the provided accounts, sorted by address
+func (fe *fileExporter) Export(accounts map[string]*data.AccountInfoWithStakeValues) error {
+	if fe.bufferWriter == nil {
+		return ErrExportNotStarted
+	}
+
+	addresses := make([]string, 0, len(accounts))
+	for address := range accounts {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	for _, address := range addresses {
+		err := fe.writeAccount(address, accounts[address])
+		if err != nil {
+			return err
+		}
+
+		fe.numRows++
+	}
+
+	return nil
+}
+
+func (fe *fileExporter) writeAccount(address string, account *data.AccountInfoWithStakeValues) error {
+	if fe.format == FormatCSV {
+		return fe.csvWriter.Write(csvRow(address, account))
+	}
+
+	accountCopy := *account
+	accountCopy.Address = address
+	accountBytes, err := json.Marshal(accountCopy)
+	if err != nil {
+		return err
+	}
+
+	_, err = fe.bufferWriter.Write(append(accountBytes, '\n'))
+	return err
+}
+
+// Finish will flush all the exported accounts and will write the manifest of the export. The export file is renamed
+// to its final name only after all the accounts were written
+func (fe *fileExporter) Finish(blockInfo *data.BlockInfo) error {
+	if fe.bufferWriter == nil {
+		return ErrExportNotStarted
+	}
+
+	err := fe.flush()
+	if err != nil {
+		return err
+	}
+
+	manifest := &data.ExportManifest{
+		Epoch:     fe.epoch,
+		BlockInfo: blockInfo,
+		NumRows:   fe.numRows,
+		Format:    fe.format,
+		FileName:  fe.fileName,
+		Timestamp: time.Now().Unix(),
+	}
+
+	if fe.isStdout() {
+		log.Info("exported accounts to stdout", "epoch", fe.epoch, "num rows", fe.numRows)
+		fe.reset()
+		return nil
+	}
+
+	err = fe.closeFile()
+	if err != nil {
+		return err
+	}
+
+	exportFilePath := filepath.Join(fe.outputPath, fe.fileName)
+	err = os.Rename(exportFilePath+tmpExtension, exportFilePath)
+	if err != nil {
+		return err
+	}
+
+	manifestBytes, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	err = ioutil.WriteFile(fe.manifestPath(fe.epoch), manifestBytes, 0644)
+	if err != nil {
+		return err
+	}
+
+	log.Info("exported accounts", "file", exportFilePath, "num rows", fe.numRows)
+	fe.reset()
+
+	return nil
+}
+
+// Abort will drop the current export, if any
+func (fe *fileExporter) Abort() {
+	if fe.file == nil {
+		fe.reset()
+		return
+	}
+
+	err := fe.file.Close()
+	if err != nil {
+		log.Warn("cannot close export file", "error", err)
+	}
+
+	err = os.Remove(fe.file.Name())
+	if err != nil {
+		log.Warn("cannot remove export file", "error", err)
+	}
+
+	fe.reset()
+}
+
+// IsExported returns true if the manifest of the provided epoch exists. Exports to stdout are never considered done
+func (fe *fileExporter) IsExported(epoch uint32) (bool, error) {
+	if fe.isStdout() {
+		return false, nil
+	}
+
+	_, err := os.Stat(fe.manifestPath(epoch))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+func (fe *fileExporter) flush() error {
+	if fe.csvWriter != nil {
+		fe.csvWriter.Flush()
+		err := fe.csvWriter.Error()
+		if err != nil {
+			return err
+		}
+	}
+
+	err := fe.bufferWriter.Flush()
+	if err != nil {
+		return err
+	}
+
+	if fe.gzipWriter != nil {
+		return fe.gzipWriter.Close()
+	}
+
+	return nil
+}
+
+func (fe *fileExporter) closeFile() error {
+	err := fe.file.Sync()
+	if err != nil {
+		return err
+	}
+
+	return fe.file.Close()
+}
+
+func (fe *fileExporter) reset() {
+	fe.file = nil
+	fe.gzipWriter = nil
+	fe.bufferWriter = nil
+	fe.csvWriter = nil
+}
+
+func (fe *fileExporter) isStdout() bool {
+	return fe.outputPath == stdoutOutputPath
+}
+
+func (fe *fileExporter) computeFileName(epoch uint32) string {
+	fileName := fmt.Sprintf("%s_%d.%s", filesPrefix, epoch, fe.format)
+	if fe.compress {
+		fileName += gzipExtension
+	}
+
+	return fileName
+}
+
+func (fe *fileExporter) manifestPath(epoch uint32) string {
+	return filepath.Join(fe.outputPath, fmt.Sprintf("%s_%d.manifest.json", filesPrefix, epoch))
+}
+
+// IsInterfaceNil returns true if the value under the interface is nil
+func (fe *fileExporter) IsInterfaceNil() bool {
+	return fe == nil
+}
@@ -0,0 +1,130 @@
+package exporter
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	dataIndexer "github.com/multiversx/mx-chain-es-indexer-go/data"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestAccounts() map[string]*data.AccountInfoWithStakeValues {
+	return map[string]*data.AccountInfoWithStakeValues{
+		"erd1b": {
+			AccountInfo: dataIndexer.AccountInfo{Balance: "2", BalanceNum: 0.5},
+			StakeInfo:   data.StakeInfo{Delegation: "10", TotalStake: "10"},
+		},
+		"erd1a": {
+			AccountInfo: dataIndexer.AccountInfo{Balance: "1"},
+		},
+	}
+}
+
+func TestNewFileExporter(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewFileExporter(config.ExportConfig{Format: "xml", OutputPath: "-"}, &bytes.Buffer{})
+	require.True(t, errors.Is(err, ErrInvalidExportFormat))
+
+	_, err = NewFileExporter(config.ExportConfig{Format: FormatCSV}, &bytes.Buffer{})
+	require.Equal(t, ErrEmptyOutputPath, err)
+
+	_, err = NewFileExporter(config.ExportConfig{Format: FormatCSV, OutputPath: "-"}, nil)
+	require.Equal(t, ErrNilWriter, err)
+
+	fe, err := NewFileExporter(config.ExportConfig{Format: FormatNDJSON, OutputPath: "-"}, &bytes.Buffer{})
+	require.Nil(t, err)
+	require.Equal(t, ErrExportNotStarted, fe.Export(createTestAccounts()))
+}
+
+func TestFileExporter_ExportCSVToStdout(t *testing.T) {
+	t.Parallel()
+
+	buff := &bytes.Buffer{}
+	fe, _ := NewFileExporter(config.ExportConfig{Format: FormatCSV, OutputPath: "-"}, buff)
+
+	require.Nil(t, fe.Start(10))
+	require.Nil(t, fe.Export(createTestAccounts()))
+	require.Nil(t, fe.Finish(&data.BlockInfo{Nonce: 1}))
+
+	lines := strings.Split(strings.TrimSpace(buff.String()), "\n")
+	require.Len(t, lines, 3)
+	require.True(t, strings.HasPrefix(lines[0], "address,balance,balanceNum,"))
+	require.True(t, strings.HasPrefix(lines[1], "erd1a,1,0,"))
+	require.True(t, strings.HasPrefix(lines[2], "erd1b,2,0.5,"))
+
+	exported, err := fe.IsExported(10)
+	require.Nil(t, err)
+	require.False(t, exported)
+}
+
+func TestFileExporter_ExportCompressedNDJSONToFile(t *testing.T) {
+	t.Parallel()
+
+	outputPath := t.TempDir()
+	fe, _ := NewFileExporter(config.ExportConfig{Format: FormatNDJSON, OutputPath: outputPath, Compress: true}, &bytes.Buffer{})
+
+	exported, err := fe.IsExported(10)
+	require.Nil(t, err)
+	require.False(t, exported)
+
+	blockInfo := &data.BlockInfo{Hash: "hash", Nonce: 5}
+	require.Nil(t, fe.Start(10))
+	require.Nil(t, fe.Export(createTestAccounts()))
+	require.Nil(t, fe.Finish(blockInfo))
+
+	exported, err = fe.IsExported(10)
+	require.Nil(t, err)
+	require.True(t, exported)
+
+	file, err := os.Open(filepath.Join(outputPath, "accounts_10.ndjson.gz"))
+	require.Nil(t, err)
+	defer func() {
+		_ = file.Close()
+	}()
+
+	gzipReader, err := gzip.NewReader(file)
+	require.Nil(t, err)
+	content, err := ioutil.ReadAll(gzipReader)
+	require.Nil(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	require.Len(t, lines, 2)
+	account := &data.AccountInfoWithStakeValues{}
+	require.Nil(t, json.Unmarshal([]byte(lines[1]), account))
+	require.Equal(t, "erd1b", account.Address)
+	require.Equal(t, "10", account.Delegation)
+
+	manifestBytes, err := ioutil.ReadFile(filepath.Join(outputPath, "accounts_10.manifest.json"))
+	require.Nil(t, err)
+	manifest := &data.ExportManifest{}
+	require.Nil(t, json.Unmarshal(manifestBytes, manifest))
+	require.Equal(t, uint32(10), manifest.Epoch)
+	require.Equal(t, uint64(2), manifest.NumRows)
+	require.Equal(t, blockInfo, manifest.BlockInfo)
+	require.Equal(t, "accounts_10.ndjson.gz", manifest.FileName)
+}
+
+func TestFileExporter_AbortShouldRemoveTheFile(t *testing.T) {
+	t.Parallel()
+
+	outputPath := t.TempDir()
+	fe, _ := NewFileExporter(config.ExportConfig{Format: FormatCSV, OutputPath: outputPath}, &bytes.Buffer{})
+
+	require.Nil(t, fe.Start(3))
+	require.Nil(t, fe.Export(createTestAccounts()))
+	fe.Abort()
+
+	files, err := ioutil.ReadDir(outputPath)
+	require.Nil(t, err)
+	require.Empty(t, files)
+}
@@ -9,20 +9,43 @@ import (
 // ElasticClientHandler defines what an elastic client should be able to do
 type ElasticClientHandler interface {
 	PutPolicy(policyName string, policy *bytes.Buffer) error
+	GetPolicy(policyName string) ([]byte, error)
 	PutMapping(targetIndex string, body *bytes.Buffer) error
+	PutIndexTemplate(templateName string, template *bytes.Buffer) error
 	CreateIndexWithMapping(index string, mapping *bytes.Buffer) error
 	CheckIfIndexExists(index string) (bool, error)
+	GetAliasIndices(alias string) ([]string, error)
+	UpdateAliases(alias string, oldIndices []string, newIndex string) error
 	DoRequest(index, documentID string, buff *bytes.Buffer) error
 	DoBulkRequest(buff *bytes.Buffer, index string) error
+	DoBulkRequestWithItems(buff *bytes.Buffer, index string) (*data.BulkRequestResponse, error)
 	DoMultiGet(ids []string, index string) ([]byte, error)
 	DoScrollRequestAllDocuments(index string, body []byte, handlerFunc func(responseBytes []byte) error) error
+	DoPointInTimeRequestAllDocuments(index string, body []byte, args data.PointInTimeArgs, handlerFunc func(responseBytes []byte) error) error
+	DoSearchRequest(index string, body []byte) ([]byte, error)
+	RefreshIndex(index string) error
+	GetIndices(pattern string) ([]string, error)
+	DeleteIndex(index string) error
+	DeleteByQuery(index string, body []byte) error
+	IsInterfaceNil() bool
+}
+
+// AccountsExporter defines what an accounts' exporter should be able to do
+type AccountsExporter interface {
+	Start(epoch uint32) error
+	Export(accounts map[string]*data.AccountInfoWithStakeValues) error
+	Finish(blockInfo *data.BlockInfo) error
+	Abort()
+	IsExported(epoch uint32) (bool, error)
 	IsInterfaceNil() bool
 }
 
 // AccountsIndexerHandler defines what an accounts' indexer should be able to do
 type AccountsIndexerHandler interface {
 	GetAccounts(addresses []string, index string) (map[string]*data.AccountInfoWithStakeValues, error)
+	GetAccountsStrict(addresses []string, index string) (map[string]*data.AccountInfoWithStakeValues, error)
 	IndexAccounts(accounts map[string]*data.AccountInfoWithStakeValues, index string) error
+	IndexStakeHistory(entries []*data.AccountStakeHistory, index string) error
 }
 
 // AccountsProcessorHandler defines what an accounts' processor should be able to do
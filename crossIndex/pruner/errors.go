@@ -0,0 +1,18 @@
+package pruner
+
+import "errors"
+
+// ErrNoDestinationClient signals that no destination client has been provided
+var ErrNoDestinationClient = errors.New("no destination client")
+
+// ErrEmptyIndexPrefix signals that an empty accounts index prefix has been provided
+var ErrEmptyIndexPrefix = errors.New("empty accounts index prefix")
+
+// ErrNoRetentionRule signals that neither the number of indices nor the number of epochs to keep has been provided
+var ErrNoRetentionRule = errors.New("no retention rule, set the number of indices or the number of epochs to keep")
+
+// ErrNilWriter signals that a nil writer has been provided
+var ErrNilWriter = errors.New("nil writer")
+
+// ErrInvalidKeepLast signals that an invalid number of indices to keep has been provided
+var ErrInvalidKeepLast = errors.New("invalid number of indices to keep")
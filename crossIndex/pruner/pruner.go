@@ -0,0 +1,229 @@
+package pruner
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	logger "github.com/multiversx/mx-chain-logger-go"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex"
+)
+
+const (
+	// ActionKeep marks an index that is kept
+	ActionKeep = "keep"
+	// ActionDelete marks an index that is deleted
+	ActionDelete = "delete"
+
+	reasonAlias      = "behind the accounts alias"
+	reasonKeepLast   = "one of the newest indices"
+	reasonKeepEpochs = "newer than the epochs limit"
+	reasonExpired    = "expired"
+)
+
+var log = logger.GetOrCreate("pruner")
+
+// ArgsPruner holds the arguments needed to create a new pruner
+type ArgsPruner struct {
+	DestinationClients  []crossIndex.ElasticClientHandler
+	AccountsIndexPrefix string
+	AccountsAlias       string
+	KeepLast            int
+	KeepEpochs          uint32
+}
+
+// IndexDecision holds what happens with an accounts index of a cluster, and why
+type IndexDecision struct {
+	Cluster string
+	Index   string
+	Epoch   uint32
+	Action  string
+	Reason  string
+}
+
+type epochIndex struct {
+	name  string
+	epoch uint32
+}
+
+type pruner struct {
+	destinationClients  []crossIndex.ElasticClientHandler
+	accountsIndexPrefix string
+	accountsAlias       string
+	keepLast            int
+	keepEpochs          uint32
+}
+
+// NewPruner will create a new pruner. An index is kept if it is one of the newest KeepLast indices or if its epoch is
+// newer than KeepEpochs epochs from the newest index of the cluster. The index behind the accounts alias is always kept
+func NewPruner(args ArgsPruner) (*pruner, error) {
+	if len(args.DestinationClients) == 0 {
+		return nil, ErrNoDestinationClient
+	}
+	for idx, dstClient := range args.DestinationClients {
+		if check.IfNil(dstClient) {
+			return nil, fmt.Errorf("%w for destination client, index %d", crossIndex.ErrNilElasticClient, idx)
+		}
+	}
+	if args.AccountsIndexPrefix == "" {
+		return nil, ErrEmptyIndexPrefix
+	}
+	if args.KeepLast < 0 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidKeepLast, args.KeepLast)
+	}
+	if args.KeepLast == 0 && args.KeepEpochs == 0 {
+		return nil, ErrNoRetentionRule
+	}
+
+	return &pruner{
+		destinationClients:  args.DestinationClients,
+		accountsIndexPrefix: args.AccountsIndexPrefix,
+		accountsAlias:       args.AccountsAlias,
+		keepLast:            args.KeepLast,
+		keepEpochs:          args.KeepEpochs,
+	}, nil
+}
+
+// Plan will list the accounts indices of every destination cluster and will decide which of them are deleted
+func (p *pruner) Plan() ([]*IndexDecision, error) {
+	decisions := make([]*IndexDecision, 0)
+	for idx, dstClient := range p.destinationClients {
+		clusterDecisions, err := p.planCluster(fmt.Sprintf("cluster-%d", idx), dstClient)
+		if err != nil {
+			return nil, err
+		}
+
+		decisions = append(decisions, clusterDecisions...)
+	}
+
+	return decisions, nil
+}
+
+func (p *pruner) planCluster(cluster string, dstClient crossIndex.ElasticClientHandler) ([]*IndexDecision, error) {
+	indices, err := dstClient.GetIndices(p.accountsIndexPrefix + "_*")
+	if err != nil {
+		return nil, err
+	}
+
+	aliasIndices := make(map[string]struct{})
+	if p.accountsAlias != "" {
+		indicesWithAlias, errGet := dstClient.GetAliasIndices(p.accountsAlias)
+		if errGet != nil {
+			return nil, errGet
+		}
+
+		for _, index := range indicesWithAlias {
+			aliasIndices[index] = struct{}{}
+		}
+	}
+
+	epochIndices := p.parseEpochIndices(indices)
+	if len(epochIndices) == 0 {
+		return nil, nil
+	}
+
+	newestEpoch := epochIndices[0].epoch
+	decisions := make([]*IndexDecision, 0, len(epochIndices))
+	for position, index := range epochIndices {
+		decision := &IndexDecision{
+			Cluster: cluster,
+			Index:   index.name,
+			Epoch:   index.epoch,
+			Action:  ActionKeep,
+		}
+
+		_, isBehindAlias := aliasIndices[index.name]
+		switch {
+		case isBehindAlias:
+			decision.Reason = reasonAlias
+		case position < p.keepLast:
+			decision.Reason = reasonKeepLast
+		case p.keepEpochs > 0 && newestEpoch-index.epoch < p.keepEpochs:
+			decision.Reason = reasonKeepEpochs
+		default:
+			decision.Action = ActionDelete
+			decision.Reason = reasonExpired
+		}
+
+		decisions = append(decisions, decision)
+	}
+
+	return decisions, nil
+}
+
+// parseEpochIndices returns the indices named <prefix>_<epoch>, from the newest epoch to the oldest one
+func (p *pruner) parseEpochIndices(indices []string) []epochIndex {
+	epochIndices := make([]epochIndex, 0, len(indices))
+	for _, index := range indices {
+		epochStr := strings.TrimPrefix(index, p.accountsIndexPrefix+"_")
+		epoch, err := strconv.ParseUint(epochStr, 10, 32)
+		if epochStr == index || err != nil {
+			continue
+		}
+
+		epochIndices = append(epochIndices, epochIndex{name: index, epoch: uint32(epoch)})
+	}
+
+	sort.Slice(epochIndices, func(i, j int) bool {
+		return epochIndices[i].epoch > epochIndices[j].epoch
+	})
+
+	return epochIndices
+}
+
+// Prune will delete the indices marked for deletion by the provided plan
+func (p *pruner) Prune(decisions []*IndexDecision) error {
+	for idx, dstClient := range p.destinationClients {
+		cluster := fmt.Sprintf("cluster-%d", idx)
+		for _, decision := range decisions {
+			if decision.Cluster != cluster || decision.Action != ActionDelete {
+				continue
+			}
+
+			err := dstClient.DeleteIndex(decision.Index)
+			if err != nil {
+				return err
+			}
+
+			log.Info("deleted accounts index", "cluster", cluster, "index", decision.Index)
+		}
+	}
+
+	return nil
+}
+
+// CountDeletions returns the number of indices marked for deletion by the provided plan
+func CountDeletions(decisions []*IndexDecision) int {
+	numDeletions := 0
+	for _, decision := range decisions {
+		if decision.Action == ActionDelete {
+			numDeletions++
+		}
+	}
+
+	return numDeletions
+}
+
+// PrintPlan will write the provided plan as a table
+func PrintPlan(w io.Writer, decisions []*IndexDecision) error {
+	if w == nil {
+		return ErrNilWriter
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "CLUSTER\tINDEX\tEPOCH\tACTION\tREASON")
+	for _, decision := range decisions {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\n", decision.Cluster, decision.Index, decision.Epoch, decision.Action, decision.Reason)
+	}
+
+	return tw.Flush()
+}
+
+// IsInterfaceNil returns true if the value under the interface is nil
+func (p *pruner) IsInterfaceNil() bool {
+	return p == nil
+}
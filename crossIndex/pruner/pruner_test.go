@@ -0,0 +1,143 @@
+package pruner
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+const accountsIndexPrefix = "accounts-000001"
+
+func createArgs(clients ...crossIndex.ElasticClientHandler) ArgsPruner {
+	return ArgsPruner{
+		DestinationClients:  clients,
+		AccountsIndexPrefix: accountsIndexPrefix,
+		AccountsAlias:       "accounts-with-stake",
+		KeepLast:            2,
+	}
+}
+
+func createClient(indices []string, aliasIndices []string, deleted *[]string) *mocks.ElasticClientStub {
+	return &mocks.ElasticClientStub{
+		GetIndicesCalled: func(pattern string) ([]string, error) {
+			return indices, nil
+		},
+		GetAliasIndicesCalled: func(alias string) ([]string, error) {
+			return aliasIndices, nil
+		},
+		DeleteIndexCalled: func(index string) error {
+			*deleted = append(*deleted, index)
+			return nil
+		},
+	}
+}
+
+func TestNewPruner(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewPruner(createArgs())
+	require.Equal(t, ErrNoDestinationClient, err)
+
+	args := createArgs(nil)
+	_, err = NewPruner(args)
+	require.ErrorIs(t, err, crossIndex.ErrNilElasticClient)
+
+	args = createArgs(&mocks.ElasticClientStub{})
+	args.AccountsIndexPrefix = ""
+	_, err = NewPruner(args)
+	require.Equal(t, ErrEmptyIndexPrefix, err)
+
+	args = createArgs(&mocks.ElasticClientStub{})
+	args.KeepLast = -1
+	_, err = NewPruner(args)
+	require.ErrorIs(t, err, ErrInvalidKeepLast)
+
+	args = createArgs(&mocks.ElasticClientStub{})
+	args.KeepLast = 0
+	_, err = NewPruner(args)
+	require.Equal(t, ErrNoRetentionRule, err)
+
+	p, err := NewPruner(createArgs(&mocks.ElasticClientStub{}))
+	require.Nil(t, err)
+	require.False(t, p.IsInterfaceNil())
+}
+
+func TestPruner_PlanKeepLast(t *testing.T) {
+	t.Parallel()
+
+	deleted := make([]string, 0)
+	indices := []string{"accounts-000001_9", "accounts-000001_12", "accounts-000001_10", "accounts-000001_11", "accounts-000001_old", "accounts-000001_3"}
+	// the alias was not moved to the newest indices, e.g. the sanity gate marked them as unhealthy
+	esClient := createClient(indices, []string{"accounts-000001_9"}, &deleted)
+	p, _ := NewPruner(createArgs(esClient))
+
+	decisions, err := p.Plan()
+	require.Nil(t, err)
+	require.Len(t, decisions, 5)
+
+	expected := []struct {
+		index  string
+		action string
+		reason string
+	}{
+		{"accounts-000001_12", ActionKeep, reasonKeepLast},
+		{"accounts-000001_11", ActionKeep, reasonKeepLast},
+		{"accounts-000001_10", ActionDelete, reasonExpired},
+		{"accounts-000001_9", ActionKeep, reasonAlias},
+		{"accounts-000001_3", ActionDelete, reasonExpired},
+	}
+	for idx, decision := range decisions {
+		require.Equal(t, "cluster-0", decision.Cluster)
+		require.Equal(t, expected[idx].index, decision.Index)
+		require.Equal(t, expected[idx].action, decision.Action)
+		require.Equal(t, expected[idx].reason, decision.Reason)
+	}
+	require.Equal(t, 2, CountDeletions(decisions))
+
+	err = p.Prune(decisions)
+	require.Nil(t, err)
+	require.Equal(t, []string{"accounts-000001_10", "accounts-000001_3"}, deleted)
+}
+
+func TestPruner_PlanKeepEpochsOnEveryCluster(t *testing.T) {
+	t.Parallel()
+
+	deletedFirst, deletedSecond := make([]string, 0), make([]string, 0)
+	firstClient := createClient([]string{"accounts-000001_20", "accounts-000001_18", "accounts-000001_15"}, nil, &deletedFirst)
+	secondClient := createClient([]string{"accounts-000001_10", "accounts-000001_5"}, nil, &deletedSecond)
+
+	args := createArgs(firstClient, secondClient)
+	args.KeepLast = 0
+	args.KeepEpochs = 5
+	p, _ := NewPruner(args)
+
+	decisions, err := p.Plan()
+	require.Nil(t, err)
+	require.Equal(t, 2, CountDeletions(decisions))
+
+	err = p.Prune(decisions)
+	require.Nil(t, err)
+	require.Equal(t, []string{"accounts-000001_15"}, deletedFirst)
+	require.Equal(t, []string{"accounts-000001_5"}, deletedSecond)
+}
+
+func TestPrintPlan(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, ErrNilWriter, PrintPlan(nil, nil))
+
+	buff := &bytes.Buffer{}
+	err := PrintPlan(buff, []*IndexDecision{
+		{Cluster: "cluster-0", Index: "accounts-000001_3", Epoch: 3, Action: ActionDelete, Reason: reasonExpired},
+	})
+	require.Nil(t, err)
+
+	lines := strings.Split(strings.TrimSpace(buff.String()), "\n")
+	require.Len(t, lines, 2)
+	require.True(t, strings.HasPrefix(lines[0], "CLUSTER"))
+	require.Equal(t, []string{"cluster-0", "accounts-000001_3", "3", "delete", "expired"}, strings.Fields(lines[1]))
+}
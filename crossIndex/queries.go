@@ -28,3 +28,100 @@ func GetAll() *bytes.Buffer {
 
 	return &encoded
 }
+
+// SnapshotSumAggregations maps the name of the sum aggregations of a snapshot to the summed field
+var SnapshotSumAggregations = map[string]string{
+	"totalStake":       "totalStakeNum",
+	"totalUnDelegated": "totalUnDelegateNum",
+	"energy":           "energyNum",
+	"lkMexStake":       "lkMexStakeNum",
+}
+
+// SnapshotSourceFields maps the name of every stake source to the fields that are set for the accounts of that source
+var SnapshotSourceFields = map[string][]string{
+	"legacy-delegation": {"delegationLegacyActiveNum", "delegationLegacyWaitingNum", "unDelegateLegacyNum"},
+	"validators":        {"validatorsActiveNum", "validatorsTopUpNum", "unDelegateValidatorNum"},
+	"delegators":        {"delegationNum", "unDelegateDelegationNum"},
+	"lkmex":             {"lkMexStakeNum"},
+	"energy":            {"energyNum"},
+}
+
+// GetSnapshotStats will return a query that computes the stake totals of an accounts index and the number of accounts
+// of every stake source
+func GetSnapshotStats() *bytes.Buffer {
+	aggregations := object{}
+	for name, field := range SnapshotSumAggregations {
+		aggregations[name] = object{
+			"sum": object{
+				"field": field,
+			},
+		}
+	}
+	for source, fields := range SnapshotSourceFields {
+		shouldExist := make([]interface{}, 0, len(fields))
+		for _, field := range fields {
+			shouldExist = append(shouldExist, object{
+				"exists": object{
+					"field": field,
+				},
+			})
+		}
+
+		aggregations[source] = object{
+			"filter": object{
+				"bool": object{
+					"should":               shouldExist,
+					"minimum_should_match": 1,
+				},
+			},
+		}
+	}
+
+	obj := object{
+		"size":             0,
+		"track_total_hits": true,
+		"aggs":             aggregations,
+	}
+
+	encoded, _ := EncodeQuery(obj)
+
+	return &encoded
+}
+
+// GetTopAccounts will return a query that fetches the accounts with the greatest values of the provided field
+func GetTopAccounts(field string, size int) *bytes.Buffer {
+	obj := object{
+		"size": size,
+		"query": object{
+			"exists": object{
+				"field": field,
+			},
+		},
+		"sort": []interface{}{
+			object{
+				field: object{
+					"order": "desc",
+				},
+			},
+		},
+	}
+
+	encoded, _ := EncodeQuery(obj)
+
+	return &encoded
+}
+
+// GetByEpoch will return a query that matches the documents of the provided epoch
+func GetByEpoch(epoch uint32) *bytes.Buffer {
+	obj := object{
+		"query": object{
+			"term": object{
+				"epoch": epoch,
+			},
+		},
+	}
+
+	encoded, _ := EncodeQuery(obj)
+
+	return &encoded
+}
@@ -0,0 +1,77 @@
+package reindexer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+)
+
+// checkpoint holds the progress of reindexing an accounts index
+type checkpoint struct {
+	Epoch       uint32 `json:"epoch"`
+	Index       string `json:"index"`
+	NumBulks    int    `json:"numBulks"`
+	LastAddress string `json:"lastAddress"`
+	Done        bool   `json:"done"`
+	// FailedDestinations holds the positions of the destination clusters that missed some accounts of the index
+	FailedDestinations []int `json:"failedDestinations,omitempty"`
+}
+
+type checkpointStorer struct {
+	filePath string
+}
+
+func newCheckpointStorer(filePath string) *checkpointStorer {
+	return &checkpointStorer{
+		filePath: filePath,
+	}
+}
+
+func (cs *checkpointStorer) isEnabled() bool {
+	return cs.filePath != ""
+}
+
+// load will return the saved checkpoint. A nil checkpoint is returned if nothing was saved yet
+func (cs *checkpointStorer) load() (*checkpoint, error) {
+	if !cs.isEnabled() {
+		return nil, nil
+	}
+
+	fileBytes, err := ioutil.ReadFile(cs.filePath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read checkpoint file %s: %w", cs.filePath, err)
+	}
+
+	cp := &checkpoint{}
+	err = json.Unmarshal(fileBytes, cp)
+	if err != nil {
+		return nil, fmt.Errorf("cannot unmarshal checkpoint file %s: %w", cs.filePath, err)
+	}
+
+	return cp, nil
+}
+
+// save will write the provided checkpoint in a temporary file and then will replace the checkpoint file with it,
+// so an interrupted save will not corrupt the previous checkpoint
+func (cs *checkpointStorer) save(cp *checkpoint) error {
+	if !cs.isEnabled() {
+		return nil
+	}
+
+	cpBytes, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+
+	tmpFilePath := cs.filePath + ".tmp"
+	err = ioutil.WriteFile(tmpFilePath, cpBytes, 0644)
+	if err != nil {
+		return fmt.Errorf("cannot write checkpoint file %s: %w", tmpFilePath, err)
+	}
+
+	return os.Rename(tmpFilePath, cs.filePath)
+}
@@ -0,0 +1,42 @@
+package reindexer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckpointStorer_DisabledShouldDoNothing(t *testing.T) {
+	t.Parallel()
+
+	cs := newCheckpointStorer("")
+	require.Nil(t, cs.save(&checkpoint{Index: "accounts-000001_1"}))
+
+	cp, err := cs.load()
+	require.Nil(t, err)
+	require.Nil(t, cp)
+}
+
+func TestCheckpointStorer_SaveAndLoad(t *testing.T) {
+	t.Parallel()
+
+	cs := newCheckpointStorer(filepath.Join(t.TempDir(), "checkpoint.json"))
+
+	cp, err := cs.load()
+	require.Nil(t, err)
+	require.Nil(t, cp)
+
+	savedCheckpoint := &checkpoint{
+		Epoch:       10,
+		Index:       "accounts-000001_10",
+		NumBulks:    3,
+		LastAddress: "erd1",
+	}
+	err = cs.save(savedCheckpoint)
+	require.Nil(t, err)
+
+	cp, err = cs.load()
+	require.Nil(t, err)
+	require.Equal(t, savedCheckpoint, cp)
+}
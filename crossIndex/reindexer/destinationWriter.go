@@ -0,0 +1,242 @@
+package reindexer
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+)
+
+// destinationQueueSize is the number of pages a destination can fall behind before the reading of the source waits for it
+const destinationQueueSize = 4
+
+// accountsPage holds the merged accounts of a source page, as they are written in every destination
+type accountsPage struct {
+	number      int
+	lastAddress string
+	accounts    map[string]*data.AccountInfoWithStakeValues
+}
+
+type destinationWorker struct {
+	position int
+	indexer  crossIndex.AccountsIndexerHandler
+	report   *data.DestinationReport
+	queue    chan *accountsPage
+	lastPage int
+	failed   bool
+}
+
+// destinationWriter writes the source pages in every destination cluster, each of them by its own worker, so a slow
+// destination does not slow down the others for as long as it has room in its queue. The progress is saved only up to
+// the last page written by all the destinations that did not fail
+type destinationWriter struct {
+	index        string
+	policy       *writePolicy
+	workers      []*destinationWorker
+	saveProgress func(numBulks int, lastAddress string, failedPositions []int) error
+
+	mut               sync.Mutex
+	wg                sync.WaitGroup
+	pendingPages      map[int]string
+	lastSubmittedPage int
+	savedPage         int
+	saveErr           error
+}
+
+// newDestinationWriter will create a destinationWriter and will start its workers. The destinations from the provided
+// failed positions are not written anymore
+func newDestinationWriter(
+	index string,
+	destinationIndexers []crossIndex.AccountsIndexerHandler,
+	destinationReports []*data.DestinationReport,
+	failedPositions []int,
+	policy *writePolicy,
+	startPage int,
+	saveProgress func(numBulks int, lastAddress string, failedPositions []int) error,
+) *destinationWriter {
+	dw := &destinationWriter{
+		index:             index,
+		policy:            policy,
+		workers:           make([]*destinationWorker, 0, len(destinationIndexers)),
+		saveProgress:      saveProgress,
+		pendingPages:      make(map[int]string),
+		lastSubmittedPage: startPage,
+		savedPage:         startPage,
+	}
+
+	failed := make(map[int]struct{}, len(failedPositions))
+	for _, position := range failedPositions {
+		failed[position] = struct{}{}
+	}
+
+	for position, indexer := range destinationIndexers {
+		_, isFailed := failed[position]
+		worker := &destinationWorker{
+			position: position,
+			indexer:  indexer,
+			report:   destinationReports[position],
+			queue:    make(chan *accountsPage, destinationQueueSize),
+			lastPage: startPage,
+			failed:   isFailed,
+		}
+		dw.workers = append(dw.workers, worker)
+
+		dw.wg.Add(1)
+		go dw.runWorker(worker)
+	}
+
+	return dw
+}
+
+func (dw *destinationWriter) runWorker(worker *destinationWorker) {
+	defer dw.wg.Done()
+
+	for page := range worker.queue {
+		if dw.isFailed(worker) {
+			// the queue is drained, so the reading is not blocked by a failed destination
+			continue
+		}
+
+		err := worker.indexer.IndexAccounts(page.accounts, dw.index)
+		dw.onPageWritten(worker, page, err)
+	}
+}
+
+func (dw *destinationWriter) onPageWritten(worker *destinationWorker, page *accountsPage, err error) {
+	dw.mut.Lock()
+	defer dw.mut.Unlock()
+
+	if err != nil {
+		worker.failed = true
+		worker.report.Status = data.DestinationStatusFailed
+		worker.report.Error = err.Error()
+		log.Error("cannot write accounts in destination", "destination", worker.report.Name, "bulk", page.number, "error", err)
+	} else {
+		worker.lastPage = page.number
+		worker.report.NumBulks++
+		worker.report.NumIndexedAccounts += len(page.accounts)
+		log.Info("indexed accounts", "destination", worker.report.Name, "bulk", page.number, "queued bulks", len(worker.queue))
+	}
+
+	dw.saveWrittenPages()
+}
+
+// submit will queue the provided page on every destination that did not fail. An error is returned if the run cannot
+// succeed anymore
+func (dw *destinationWriter) submit(page *accountsPage) error {
+	dw.mut.Lock()
+	err := dw.checkLocked()
+	if err != nil {
+		dw.mut.Unlock()
+		return err
+	}
+
+	dw.pendingPages[page.number] = page.lastAddress
+	dw.lastSubmittedPage = page.number
+
+	activeWorkers := make([]*destinationWorker, 0, len(dw.workers))
+	for _, worker := range dw.workers {
+		if !worker.failed {
+			activeWorkers = append(activeWorkers, worker)
+		}
+	}
+	if len(activeWorkers) == 0 {
+		dw.saveWrittenPages()
+	}
+	dw.mut.Unlock()
+
+	for _, worker := range activeWorkers {
+		worker.queue <- page
+	}
+
+	return nil
+}
+
+// saveWrittenPages saves the progress up to the last page written by all the destinations that did not fail, together
+// with the failed destinations, so they are not considered complete on resume. Nothing is saved anymore once the run
+// cannot succeed
+func (dw *destinationWriter) saveWrittenPages() {
+	failedPositions := dw.failedPositionsLocked()
+	if !dw.policy.canSucceed(len(failedPositions)) {
+		return
+	}
+
+	writtenPage := dw.lastSubmittedPage
+	for _, worker := range dw.workers {
+		if !worker.failed && worker.lastPage < writtenPage {
+			writtenPage = worker.lastPage
+		}
+	}
+	if writtenPage <= dw.savedPage || dw.saveErr != nil {
+		return
+	}
+
+	dw.saveErr = dw.saveProgress(writtenPage, dw.pendingPages[writtenPage], failedPositions)
+	dw.savedPage = writtenPage
+	for pageNumber := range dw.pendingPages {
+		if pageNumber <= writtenPage {
+			delete(dw.pendingPages, pageNumber)
+		}
+	}
+}
+
+func (dw *destinationWriter) checkLocked() error {
+	if dw.saveErr != nil {
+		return dw.saveErr
+	}
+
+	return dw.policy.checkFailures(len(dw.failedPositionsLocked()))
+}
+
+// close will wait for all the queued pages to be written and will return an error if the run cannot succeed
+func (dw *destinationWriter) close() error {
+	for _, worker := range dw.workers {
+		close(worker.queue)
+	}
+	dw.wg.Wait()
+
+	dw.mut.Lock()
+	defer dw.mut.Unlock()
+
+	return dw.checkLocked()
+}
+
+// failedPositions returns the positions of the destinations that failed, in ascending order
+func (dw *destinationWriter) failedPositions() []int {
+	dw.mut.Lock()
+	defer dw.mut.Unlock()
+
+	return dw.failedPositionsLocked()
+}
+
+func (dw *destinationWriter) failedPositionsLocked() []int {
+	positions := make([]int, 0)
+	for _, worker := range dw.workers {
+		if worker.failed {
+			positions = append(positions, worker.position)
+		}
+	}
+	sort.Ints(positions)
+
+	return positions
+}
+
+func (dw *destinationWriter) isFailed(worker *destinationWorker) bool {
+	dw.mut.Lock()
+	defer dw.mut.Unlock()
+
+	return worker.failed
+}
+
+// describeFailedDestinations returns the names and the errors of the failed destinations
+func describeFailedDestinations(destinationReports []*data.DestinationReport, failedPositions []int) []string {
+	descriptions := make([]string, 0, len(failedPositions))
+	for _, position := range failedPositions {
+		report := destinationReports[position]
+		descriptions = append(descriptions, fmt.Sprintf("%s: %s", report.Name, report.Error))
+	}
+
+	return descriptions
+}
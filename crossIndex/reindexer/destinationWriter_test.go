@@ -0,0 +1,161 @@
+package reindexer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/mocks"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/process/accountsIndexer"
+	"github.com/stretchr/testify/require"
+)
+
+func createDestinationWriterIndexers(numIndexers int, failingPosition int) ([]crossIndex.AccountsIndexerHandler, []int) {
+	mut := sync.Mutex{}
+	numBulks := make([]int, numIndexers)
+	indexers := make([]crossIndex.AccountsIndexerHandler, 0, numIndexers)
+	for idx := 0; idx < numIndexers; idx++ {
+		position := idx
+		acIndexer, _ := accountsIndexer.NewAccountsIndexer(&mocks.ElasticClientStub{
+			DoBulkRequestCalled: func(_ *bytes.Buffer, _ string) error {
+				if position == failingPosition {
+					return errors.New("local error")
+				}
+
+				mut.Lock()
+				numBulks[position]++
+				mut.Unlock()
+				return nil
+			},
+		}, config.BulkIndexerConfig{MaxRetries: 1, InitialBackoffInMilliseconds: 1})
+		indexers = append(indexers, acIndexer)
+	}
+
+	return indexers, numBulks
+}
+
+func createDestinationWriterReports(numReports int) []*data.DestinationReport {
+	reports := make([]*data.DestinationReport, 0, numReports)
+	for idx := 0; idx < numReports; idx++ {
+		reports = append(reports, &data.DestinationReport{Name: fmt.Sprintf("cluster-%d", idx), Status: data.DestinationStatusOK})
+	}
+
+	return reports
+}
+
+func createAccountsPage(number int) *accountsPage {
+	return &accountsPage{
+		number:      number,
+		lastAddress: fmt.Sprintf("erd1%d", number),
+		accounts: map[string]*data.AccountInfoWithStakeValues{
+			fmt.Sprintf("erd1%d", number): {},
+		},
+	}
+}
+
+func TestDestinationWriter_QuorumShouldContinueWithoutTheFailedDestination(t *testing.T) {
+	t.Parallel()
+
+	indexers, numBulks := createDestinationWriterIndexers(3, 1)
+	reports := createDestinationWriterReports(3)
+	policy, _ := newWritePolicy(config.WritePolicyConfig{Mode: WritePolicyQuorum}, 3)
+
+	savedBulks, savedAddress, savedFailed := 0, "", []int(nil)
+	dw := newDestinationWriter("accounts-000001", indexers, reports, nil, policy, 0, func(numBulks int, lastAddress string, failedPositions []int) error {
+		savedBulks, savedAddress, savedFailed = numBulks, lastAddress, failedPositions
+		return nil
+	})
+
+	for page := 1; page <= 10; page++ {
+		err := dw.submit(createAccountsPage(page))
+		require.Nil(t, err)
+	}
+
+	err := dw.close()
+	require.Nil(t, err)
+	require.Equal(t, []int{1}, dw.failedPositions())
+	require.Equal(t, []int{10, 0, 10}, numBulks)
+	require.Equal(t, 10, savedBulks)
+	require.Equal(t, "erd110", savedAddress)
+	require.Equal(t, []int{1}, savedFailed)
+
+	require.Equal(t, data.DestinationStatusOK, reports[0].Status)
+	require.Equal(t, 10, reports[0].NumBulks)
+	require.Equal(t, 10, reports[0].NumIndexedAccounts)
+	require.Equal(t, data.DestinationStatusFailed, reports[1].Status)
+	require.True(t, strings.HasSuffix(reports[1].Error, "erd11 (status: 0, type: , reason: local error)"))
+	require.Equal(t, 0, reports[1].NumIndexedAccounts)
+	require.Equal(t, []string{"cluster-1: " + reports[1].Error}, describeFailedDestinations(reports, dw.failedPositions()))
+}
+
+func TestDestinationWriter_AllShouldFailWhenADestinationFails(t *testing.T) {
+	t.Parallel()
+
+	indexers, _ := createDestinationWriterIndexers(2, 0)
+	reports := createDestinationWriterReports(2)
+	policy, _ := newWritePolicy(config.WritePolicyConfig{Mode: WritePolicyAll}, 2)
+
+	savedBulks := 0
+	dw := newDestinationWriter("accounts-000001", indexers, reports, nil, policy, 0, func(numBulks int, _ string, _ []int) error {
+		savedBulks = numBulks
+		return nil
+	})
+
+	var err error
+	for page := 1; page <= 10 && err == nil; page++ {
+		err = dw.submit(createAccountsPage(page))
+	}
+
+	errClose := dw.close()
+	require.True(t, errors.Is(errClose, ErrWritePolicyNotSatisfied))
+	require.Equal(t, []int{0}, dw.failedPositions())
+	require.Equal(t, 0, savedBulks)
+}
+
+func TestDestinationWriter_ShouldSkipThePreviouslyFailedDestinations(t *testing.T) {
+	t.Parallel()
+
+	indexers, numBulks := createDestinationWriterIndexers(2, -1)
+	reports := createDestinationWriterReports(2)
+	policy, _ := newWritePolicy(config.WritePolicyConfig{Mode: WritePolicyBestEffort}, 2)
+
+	savedBulks := 0
+	dw := newDestinationWriter("accounts-000001", indexers, reports, []int{0}, policy, 5, func(numBulks int, _ string, _ []int) error {
+		savedBulks = numBulks
+		return nil
+	})
+
+	for page := 6; page <= 8; page++ {
+		err := dw.submit(createAccountsPage(page))
+		require.Nil(t, err)
+	}
+
+	err := dw.close()
+	require.Nil(t, err)
+	require.Equal(t, []int{0, 3}, numBulks)
+	require.Equal(t, []int{0}, dw.failedPositions())
+	require.Equal(t, 8, savedBulks)
+}
+
+func TestDestinationWriter_ShouldStopOnSaveError(t *testing.T) {
+	t.Parallel()
+
+	indexers, _ := createDestinationWriterIndexers(1, -1)
+	reports := createDestinationWriterReports(1)
+	policy, _ := newWritePolicy(config.WritePolicyConfig{}, 1)
+
+	expectedErr := errors.New("cannot save")
+	dw := newDestinationWriter("accounts-000001", indexers, reports, nil, policy, 0, func(_ int, _ string, _ []int) error {
+		return expectedErr
+	})
+
+	_ = dw.submit(createAccountsPage(1))
+	err := dw.close()
+	require.Equal(t, expectedErr, err)
+}
@@ -0,0 +1,140 @@
+package reindexer
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"text/tabwriter"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/core"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+)
+
+type dryRunReindexer struct {
+	sourceIndexer    crossIndex.ElasticClientHandler
+	sourceReaderArgs data.PointInTimeArgs
+	writer           io.Writer
+}
+
+// NewDryRun returns a new instance of a reindexer that reads and merges all the accounts, but instead of writing them
+// to the destination clusters it writes a summary in the provided writer
+func NewDryRun(sourceIndexer crossIndex.ElasticClientHandler, sourceReader config.SourceReaderConfig, writer io.Writer) (*dryRunReindexer, error) {
+	if check.IfNil(sourceIndexer) {
+		return nil, fmt.Errorf("%w for sourceIndexer", crossIndex.ErrNilElasticClient)
+	}
+	if writer == nil {
+		return nil, ErrNilWriter
+	}
+	sourceReaderArgs, err := newPointInTimeArgs(sourceReader)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dryRunReindexer{
+		sourceIndexer:    sourceIndexer,
+		sourceReaderArgs: sourceReaderArgs,
+		writer:           writer,
+	}, nil
+}
+
+// ReindexAccounts will read all the accounts from the source index and merge them with the accounts with stake,
+// then it will write the summary of the accounts that would have been indexed in the destination index
+func (dr *dryRunReindexer) ReindexAccounts(sourceIndex string, destinationIndex string, restAccounts *data.AccountsData, report *data.RunReport) error {
+	numSourceAccounts := 0
+	numMergedAccounts := 0
+	foundStakeAccounts := make(map[string]struct{})
+
+	saverFunc := func(responseBytes []byte) error {
+		esAccounts, errG := getAllAccounts(responseBytes)
+		if errG != nil {
+			return errG
+		}
+
+		for address := range esAccounts {
+			if _, ok := restAccounts.AccountsWithStake[address]; ok {
+				foundStakeAccounts[address] = struct{}{}
+			}
+		}
+
+		mergedAccounts := core.MergeElasticAndRestAccounts(esAccounts, restAccounts.AccountsWithStake)
+
+		numSourceAccounts += len(esAccounts)
+		numMergedAccounts += len(mergedAccounts)
+		log.Info("dry run: merged accounts", "num source accounts", numSourceAccounts)
+
+		return nil
+	}
+
+	err := dr.sourceIndexer.DoPointInTimeRequestAllDocuments(sourceIndex, crossIndex.GetAll().Bytes(), dr.sourceReaderArgs, saverFunc)
+	if err != nil {
+		return err
+	}
+
+	report.NumMergedAccounts = numMergedAccounts
+
+	return dr.writeSummary(destinationIndex, restAccounts, numSourceAccounts, numMergedAccounts, len(foundStakeAccounts))
+}
+
+func (dr *dryRunReindexer) writeSummary(
+	destinationIndex string,
+	restAccounts *data.AccountsData,
+	numSourceAccounts int,
+	numMergedAccounts int,
+	numFoundStakeAccounts int,
+) error {
+	totalStake := big.NewInt(0)
+	totalUnDelegated := big.NewInt(0)
+	totalEnergy := big.NewInt(0)
+	for _, account := range restAccounts.AccountsWithStake {
+		addToBigInt(totalStake, account.TotalStake)
+		addToBigInt(totalUnDelegated, account.TotalUnDelegate)
+		addToBigInt(totalEnergy, account.Energy)
+	}
+
+	w := tabwriter.NewWriter(dr.writer, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "DRY RUN SUMMARY\t")
+	_, _ = fmt.Fprintf(w, "epoch\t%d\n", restAccounts.Epoch)
+	_, _ = fmt.Fprintf(w, "destination index\t%s\n", destinationIndex)
+	if restAccounts.SnapshotBlock != nil && restAccounts.SnapshotBlock.MetaBlock != nil {
+		_, _ = fmt.Fprintf(w, "snapshot metachain block\t%d (%s)\n", restAccounts.SnapshotBlock.MetaBlock.Nonce, restAccounts.SnapshotBlock.MetaBlock.Hash)
+	}
+	for _, source := range restAccounts.Sources {
+		_, _ = fmt.Fprintf(w, "accounts from source %s\t%d\n", source.Name, source.NumAccounts)
+	}
+	_, _ = fmt.Fprintf(w, "accounts with stake\t%d\n", len(restAccounts.AccountsWithStake))
+	_, _ = fmt.Fprintf(w, "accounts with stake missing from the source index\t%d\n", len(restAccounts.AccountsWithStake)-numFoundStakeAccounts)
+	_, _ = fmt.Fprintf(w, "total stake\t%s (%v)\n", totalStake.String(), core.ComputeBalanceAsFloat(totalStake.String()))
+	_, _ = fmt.Fprintf(w, "total undelegated\t%s (%v)\n", totalUnDelegated.String(), core.ComputeBalanceAsFloat(totalUnDelegated.String()))
+	_, _ = fmt.Fprintf(w, "total energy\t%s (%v)\n", totalEnergy.String(), core.ComputeBalanceAsFloat(totalEnergy.String()))
+	_, _ = fmt.Fprintf(w, "accounts in the source index\t%d\n", numSourceAccounts)
+	_, _ = fmt.Fprintf(w, "accounts that would be written\t%d\n", numMergedAccounts)
+
+	return w.Flush()
+}
+
+func addToBigInt(total *big.Int, value string) {
+	valueBig, ok := big.NewInt(0).SetString(value, 10)
+	if !ok {
+		return
+	}
+
+	total.Add(total, valueBig)
+}
+
+// IndexRunReport does nothing as the dry run does not write anything
+func (dr *dryRunReindexer) IndexRunReport(_ *data.RunReport) error {
+	return nil
+}
+
+// WasReindexed returns false as the dry run does not write anything
+func (dr *dryRunReindexer) WasReindexed(_ string, _ uint32) (bool, error) {
+	return false, nil
+}
+
+// IsInterfaceNil returns true if the value under the interface is nil
+func (dr *dryRunReindexer) IsInterfaceNil() bool {
+	return dr == nil
+}
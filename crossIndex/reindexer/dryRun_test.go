@@ -0,0 +1,47 @@
+package reindexer
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDryRunReindexer_ReindexAccountsShouldWriteSummary(t *testing.T) {
+	t.Parallel()
+
+	esClient := &mocks.ElasticClientStub{
+		DoPointInTimeRequestAllDocumentsCalled: func(index string, body []byte, args data.PointInTimeArgs, handlerFunc func(responseBytes []byte) error) error {
+			require.Equal(t, "accounts-000001", index)
+			require.Equal(t, defaultSourcePageSize, args.PageSize)
+			require.Empty(t, args.AfterID)
+			return handlerFunc([]byte(`{"hits":{"hits":[{"_id":"erd1a","_source":{"address":"erd1a","balance":"1"}},{"_id":"erd1b","_source":{"address":"erd1b","balance":"2"}}]}}`))
+		},
+	}
+
+	buff := &bytes.Buffer{}
+	dr, err := NewDryRun(esClient, config.SourceReaderConfig{}, buff)
+	require.Nil(t, err)
+
+	accountsData := &data.AccountsData{
+		Epoch: 10,
+		AccountsWithStake: map[string]*data.AccountInfoWithStakeValues{
+			"erd1a": {StakeInfo: data.StakeInfo{TotalStake: "1000000000000000000", TotalUnDelegate: "2000000000000000000", Energy: "5"}},
+			"erd1c": {StakeInfo: data.StakeInfo{TotalStake: "3000000000000000000"}},
+		},
+		Sources: []data.SourceInfo{{Name: "validators", NumAccounts: 2}},
+	}
+
+	err = dr.ReindexAccounts("accounts-000001", "accounts-000001_10", accountsData, &data.RunReport{})
+	require.Nil(t, err)
+
+	summary := buff.String()
+	require.Contains(t, summary, "accounts-000001_10")
+	require.Regexp(t, `accounts from source validators\s+2\n`, summary)
+	require.Regexp(t, `total stake\s+4000000000000000000 \(4\)\n`, summary)
+	require.Regexp(t, `accounts with stake missing from the source index\s+1\n`, summary)
+	require.Regexp(t, `accounts that would be written\s+2\n`, summary)
+}
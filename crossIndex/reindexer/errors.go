@@ -0,0 +1,36 @@
+package reindexer
+
+import "errors"
+
+// ErrNilWriter signals that a nil writer has been provided
+var ErrNilWriter = errors.New("nil writer")
+
+// ErrNilExporter signals that a nil exporter has been provided
+var ErrNilExporter = errors.New("nil exporter")
+
+// ErrNoDestination signals that neither destination clusters nor exporters have been provided
+var ErrNoDestination = errors.New("no destination clusters or exporters provided")
+
+// ErrInvalidSanityGateAction signals that an invalid sanity gate action has been provided
+var ErrInvalidSanityGateAction = errors.New("invalid sanity gate action")
+
+// ErrInvalidSanityGateThreshold signals that a negative sanity gate threshold has been provided
+var ErrInvalidSanityGateThreshold = errors.New("invalid sanity gate threshold")
+
+// ErrSnapshotDrift signals that the new accounts snapshot drifted too much from the previous one
+var ErrSnapshotDrift = errors.New("the accounts snapshot drifted too much from the previous one")
+
+// ErrInvalidWritePolicy signals that an invalid destinations write policy has been provided
+var ErrInvalidWritePolicy = errors.New("invalid destinations write policy")
+
+// ErrInvalidWriteQuorum signals that an invalid destinations write quorum has been provided
+var ErrInvalidWriteQuorum = errors.New("invalid destinations write quorum")
+
+// ErrWritePolicyNotSatisfied signals that too many destination clusters failed for the configured write policy
+var ErrWritePolicyNotSatisfied = errors.New("too many destination clusters failed for the write policy")
+
+// ErrInvalidSourceReaderConfig signals that an invalid source reader configuration has been provided
+var ErrInvalidSourceReaderConfig = errors.New("invalid source reader config")
+
+// ErrInvalidLifecyclePolicyMode signals that an invalid lifecycle policy mode has been provided
+var ErrInvalidLifecyclePolicyMode = errors.New("invalid lifecycle policy mode")
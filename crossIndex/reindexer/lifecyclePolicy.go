@@ -0,0 +1,100 @@
+package reindexer
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex"
+)
+
+const (
+	// LifecyclePolicyKeep puts the lifecycle policies that are missing and only reports the ones that differ from the files
+	LifecyclePolicyKeep = "keep"
+	// LifecyclePolicyOverwrite replaces the lifecycle policies that differ from the files
+	LifecyclePolicyOverwrite = "overwrite"
+	// LifecyclePolicyDisabled does not use lifecycle policies, for the clusters without index lifecycle management
+	LifecyclePolicyDisabled = "disabled"
+)
+
+// lifecyclePolicy decides how the lifecycle policies are put on the destination clusters and attached to the index
+// templates. Its zero value keeps the policies already found on the clusters
+type lifecyclePolicy struct {
+	disabled  bool
+	overwrite bool
+}
+
+// newLifecyclePolicy will create a new lifecyclePolicy. An empty mode keeps the policies found on the clusters
+func newLifecyclePolicy(cfg config.LifecyclePolicyConfig) (lifecyclePolicy, error) {
+	switch cfg.Mode {
+	case "", LifecyclePolicyKeep:
+		return lifecyclePolicy{}, nil
+	case LifecyclePolicyOverwrite:
+		return lifecyclePolicy{overwrite: true}, nil
+	case LifecyclePolicyDisabled:
+		return lifecyclePolicy{disabled: true}, nil
+	default:
+		return lifecyclePolicy{}, fmt.Errorf("%w: %s", ErrInvalidLifecyclePolicyMode, cfg.Mode)
+	}
+}
+
+// put will register the provided lifecycle policy on the provided cluster. If the cluster already holds a different
+// version of the policy, the difference is reported and the policy is replaced only in the overwrite mode
+func (lp lifecyclePolicy) put(esClient crossIndex.ElasticClientHandler, policyName string, policy []byte) error {
+	if lp.disabled {
+		return nil
+	}
+
+	existingPolicy, err := esClient.GetPolicy(policyName)
+	if err != nil {
+		return err
+	}
+
+	if len(existingPolicy) != 0 {
+		isSame, errCompare := isSamePolicy(existingPolicy, policy)
+		if errCompare != nil {
+			return errCompare
+		}
+		if isSame {
+			return nil
+		}
+
+		if !lp.overwrite {
+			log.Warn("the policy from the cluster differs from the one on disk, the cluster policy is kept",
+				"policy", policyName,
+				"cluster policy", string(existingPolicy),
+				"file policy", string(policy),
+			)
+			return nil
+		}
+
+		log.Warn("the policy from the cluster differs from the one on disk, it will be replaced",
+			"policy", policyName,
+			"cluster policy", string(existingPolicy),
+			"file policy", string(policy),
+		)
+	}
+
+	log.Info("put policy", "policy", policyName)
+
+	return esClient.PutPolicy(policyName, bytes.NewBuffer(policy))
+}
+
+// addToTemplate will attach the provided lifecycle policy to the given index template, unless the policies are disabled
+func (lp lifecyclePolicy) addToTemplate(template []byte, policyName string) ([]byte, error) {
+	if lp.disabled {
+		return template, nil
+	}
+
+	return addPolicyToTemplate(template, policyName)
+}
+
+// addRolloverAliasToTemplate will set the alias rolled over by the lifecycle policy of the given index template, unless
+// the policies are disabled
+func (lp lifecyclePolicy) addRolloverAliasToTemplate(template []byte, alias string) ([]byte, error) {
+	if lp.disabled {
+		return template, nil
+	}
+
+	return addRolloverAliasToTemplate(template, alias)
+}
@@ -0,0 +1,92 @@
+package reindexer
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLifecyclePolicy(t *testing.T) {
+	t.Parallel()
+
+	lp, err := newLifecyclePolicy(config.LifecyclePolicyConfig{})
+	require.Nil(t, err)
+	require.Equal(t, lifecyclePolicy{}, lp)
+
+	lp, err = newLifecyclePolicy(config.LifecyclePolicyConfig{Mode: LifecyclePolicyOverwrite})
+	require.Nil(t, err)
+	require.True(t, lp.overwrite)
+
+	lp, err = newLifecyclePolicy(config.LifecyclePolicyConfig{Mode: LifecyclePolicyDisabled})
+	require.Nil(t, err)
+	require.True(t, lp.disabled)
+
+	_, err = newLifecyclePolicy(config.LifecyclePolicyConfig{Mode: "some"})
+	require.True(t, errors.Is(err, ErrInvalidLifecyclePolicyMode))
+}
+
+func TestLifecyclePolicy_Put(t *testing.T) {
+	t.Parallel()
+
+	filePolicy := []byte(`{"policy":{"phases":{"delete":{"min_age":"30d"}}}}`)
+	createClient := func(clusterPolicy string, numPuts *int) *mocks.ElasticClientStub {
+		return &mocks.ElasticClientStub{
+			GetPolicyCalled: func(_ string) ([]byte, error) {
+				return []byte(clusterPolicy), nil
+			},
+			PutPolicyCalled: func(_ string, _ *bytes.Buffer) error {
+				*numPuts++
+				return nil
+			},
+		}
+	}
+
+	numPuts := 0
+	err := lifecyclePolicy{}.put(createClient("", &numPuts), "policy", filePolicy)
+	require.Nil(t, err)
+	require.Equal(t, 1, numPuts)
+
+	numPuts = 0
+	err = lifecyclePolicy{overwrite: true}.put(createClient(`{"phases":{"delete":{"min_age":"30d"}}}`, &numPuts), "policy", filePolicy)
+	require.Nil(t, err)
+	require.Equal(t, 0, numPuts)
+
+	numPuts = 0
+	err = lifecyclePolicy{}.put(createClient(`{"phases":{"delete":{"min_age":"90d"}}}`, &numPuts), "policy", filePolicy)
+	require.Nil(t, err)
+	require.Equal(t, 0, numPuts)
+
+	numPuts = 0
+	err = lifecyclePolicy{overwrite: true}.put(createClient(`{"phases":{"delete":{"min_age":"90d"}}}`, &numPuts), "policy", filePolicy)
+	require.Nil(t, err)
+	require.Equal(t, 1, numPuts)
+
+	err = lifecyclePolicy{disabled: true}.put(&mocks.ElasticClientStub{
+		GetPolicyCalled: func(_ string) ([]byte, error) {
+			require.Fail(t, "should have not been called")
+			return nil, nil
+		},
+	}, "policy", filePolicy)
+	require.Nil(t, err)
+}
+
+func TestLifecyclePolicy_AddToTemplate(t *testing.T) {
+	t.Parallel()
+
+	template := []byte(`{"settings":{"number_of_shards":1}}`)
+	res, err := lifecyclePolicy{disabled: true}.addToTemplate(template, "policy")
+	require.Nil(t, err)
+	require.Equal(t, template, res)
+
+	res, err = lifecyclePolicy{disabled: true}.addRolloverAliasToTemplate(template, "alias")
+	require.Nil(t, err)
+	require.Equal(t, template, res)
+
+	res, err = lifecyclePolicy{}.addToTemplate(template, "policy")
+	require.Nil(t, err)
+	require.Contains(t, string(res), lifecycleNameSetting)
+}
@@ -8,67 +8,171 @@ import (
 
 	"github.com/multiversx/mx-chain-core-go/core/check"
 	logger "github.com/multiversx/mx-chain-logger-go"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
 	"github.com/multiversx/mx-chain-tools-accounts-manager-go/core"
 	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex"
 	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
 	"github.com/multiversx/mx-chain-tools-accounts-manager-go/process/accountsIndexer"
+	"github.com/tidwall/gjson"
 )
 
 type reindexer struct {
 	sourceIndexer       crossIndex.ElasticClientHandler
 	destinationClients  []crossIndex.ElasticClientHandler
+	destinationIndexers []crossIndex.AccountsIndexerHandler
+	sourceReaderArgs    data.PointInTimeArgs
 	count               int
 	pathToIndicesConfig string
+	accountsAlias       string
+	checkpoints         *checkpointStorer
+	exporters           []crossIndex.AccountsExporter
+	sanityGate          *sanityGate
+	stakeHistoryEnabled bool
+	writePolicy         *writePolicy
+	lifecyclePolicy     lifecyclePolicy
 }
 
 var log = logger.GetOrCreate("reindexer")
 
+// ArgsReindexer holds the arguments needed to create a new reindexer
+type ArgsReindexer struct {
+	SourceIndexer       crossIndex.ElasticClientHandler
+	DestinationIndexers []crossIndex.ElasticClientHandler
+	PathToIndicesConfig string
+	AccountsAlias       string
+	CheckpointFilePath  string
+	SourceReader        config.SourceReaderConfig
+	Exporters           []crossIndex.AccountsExporter
+	SanityGate          config.SanityGateConfig
+	StakeHistoryEnabled bool
+	WritePolicy         config.WritePolicyConfig
+	BulkIndexer         config.BulkIndexerConfig
+	LifecyclePolicy     config.LifecyclePolicyConfig
+}
+
 // New returns a new instance of reindexer
-func New(
-	sourceIndexer crossIndex.ElasticClientHandler,
-	destinationIndexer []crossIndex.ElasticClientHandler,
-	pathToIndicesConfig string) (*reindexer, error,
-) {
-	if check.IfNil(sourceIndexer) {
+func New(args ArgsReindexer) (*reindexer, error) {
+	if check.IfNil(args.SourceIndexer) {
 		return nil, fmt.Errorf("%w for sourceIndexer", crossIndex.ErrNilElasticClient)
 	}
-	if pathToIndicesConfig == "" {
+	if args.PathToIndicesConfig == "" {
 		return nil, errors.New("empty path to the indices config folder")
 	}
-	for idx, dstClient := range destinationIndexer {
+	destinationIndexers := make([]crossIndex.AccountsIndexerHandler, 0, len(args.DestinationIndexers))
+	for idx, dstClient := range args.DestinationIndexers {
 		if check.IfNil(dstClient) {
 			return nil, fmt.Errorf("%w for destinationIndexer, index %d", crossIndex.ErrNilElasticClient, idx)
 		}
+
+		acIndexer, err := accountsIndexer.NewAccountsIndexer(dstClient, args.BulkIndexer)
+		if err != nil {
+			return nil, err
+		}
+		destinationIndexers = append(destinationIndexers, acIndexer)
+	}
+	for idx, exporter := range args.Exporters {
+		if check.IfNil(exporter) {
+			return nil, fmt.Errorf("%w, index %d", ErrNilExporter, idx)
+		}
+	}
+	if len(args.DestinationIndexers) == 0 && len(args.Exporters) == 0 {
+		return nil, ErrNoDestination
+	}
+	sourceReaderArgs, err := newPointInTimeArgs(args.SourceReader)
+	if err != nil {
+		return nil, err
+	}
+	gate, err := newSanityGate(args.SanityGate)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := newWritePolicy(args.WritePolicy, len(args.DestinationIndexers))
+	if err != nil {
+		return nil, err
+	}
+	lcPolicy, err := newLifecyclePolicy(args.LifecyclePolicy)
+	if err != nil {
+		return nil, err
 	}
 
 	return &reindexer{
-		sourceIndexer:       sourceIndexer,
-		destinationClients:  destinationIndexer,
-		pathToIndicesConfig: pathToIndicesConfig,
+		sourceIndexer:       args.SourceIndexer,
+		destinationClients:  args.DestinationIndexers,
+		destinationIndexers: destinationIndexers,
+		sourceReaderArgs:    sourceReaderArgs,
+		pathToIndicesConfig: args.PathToIndicesConfig,
+		accountsAlias:       args.AccountsAlias,
+		checkpoints:         newCheckpointStorer(args.CheckpointFilePath),
+		exporters:           args.Exporters,
+		sanityGate:          gate,
+		stakeHistoryEnabled: args.StakeHistoryEnabled,
+		writePolicy:         policy,
+		lifecyclePolicy:     lcPolicy,
 	}, nil
 }
 
-// ReindexAccounts will reindex all accounts from source indexer to destination indexer
-func (r *reindexer) ReindexAccounts(sourceIndex string, destinationIndex string, restAccounts *data.AccountsData) error {
-	log.Info("Create a new index with mapping")
+// ReindexAccounts will reindex all accounts from source indexer to destination indexer. If a checkpoint was saved for
+// the destination index by a previous run, the reindexing continues from it into the existing destination index. The
+// number of merged and written accounts is saved in the provided run report
+func (r *reindexer) ReindexAccounts(sourceIndex string, destinationIndex string, restAccounts *data.AccountsData, report *data.RunReport) error {
+	report.Destinations = r.createDestinationReports()
 
-	template, _, err := readTemplateAndPolicyForAccountsIndex(r.pathToIndicesConfig)
+	cp, err := r.prepareDestinationIndex(destinationIndex, restAccounts.Epoch, report)
 	if err != nil {
 		return err
 	}
 
-	templateBytes := template.Bytes()
+	err = r.startExporters(restAccounts.Epoch)
+	if err != nil {
+		return err
+	}
 
-	for _, dstClient := range r.destinationClients {
-		err = dstClient.CreateIndexWithMapping(destinationIndex, bytes.NewBuffer(templateBytes))
-		if err != nil {
-			return err
-		}
+	err = r.reindexAccounts(sourceIndex, destinationIndex, restAccounts, cp, report)
+	if err != nil {
+		r.abortExporters()
+		return err
+	}
+
+	cp.Done = true
+
+	return r.checkpoints.save(cp)
+}
+
+func (r *reindexer) reindexAccounts(
+	sourceIndex string,
+	destinationIndex string,
+	restAccounts *data.AccountsData,
+	cp *checkpoint,
+	report *data.RunReport,
+) error {
+	resumeAddress := cp.LastAddress
+	readFromAddress := resumeAddress
+	r.count = cp.NumBulks
+	if len(r.exporters) > 0 {
+		// the exports cannot be resumed, so all the accounts are read again and the pages that were indexed
+		// before the checkpoint are only exported
+		readFromAddress = ""
+		r.count = 0
 	}
 
+	writer := newDestinationWriter(
+		destinationIndex,
+		r.destinationIndexers,
+		report.Destinations,
+		cp.FailedDestinations,
+		r.writePolicy,
+		r.count,
+		func(numBulks int, lastAddress string, failedPositions []int) error {
+			cp.NumBulks = numBulks
+			cp.LastAddress = lastAddress
+			cp.FailedDestinations = failedPositions
+
+			return r.checkpoints.save(cp)
+		},
+	)
+
 	saverFunc := func(responseBytes []byte) error {
 		r.count++
-		log.Info("indexing accounts", "bulk", r.count)
 
 		esAccounts, errG := getAllAccounts(responseBytes)
 		if errG != nil {
@@ -76,50 +180,422 @@ func (r *reindexer) ReindexAccounts(sourceIndex string, destinationIndex string,
 		}
 
 		mergedAccounts := core.MergeElasticAndRestAccounts(esAccounts, restAccounts.AccountsWithStake)
+		report.NumMergedAccounts += len(mergedAccounts)
+
+		lastAddress := getLastAddress(responseBytes, resumeAddress)
+		wasIndexed := resumeAddress != "" && lastAddress <= resumeAddress
+		if !wasIndexed {
+			page := &accountsPage{
+				number:      r.count,
+				lastAddress: lastAddress,
+				accounts:    mergedAccounts,
+			}
+			errS := writer.submit(page)
+			if errS != nil {
+				return errS
+			}
+		}
+
+		return r.exportAllAccounts(mergedAccounts, report.Destinations[len(r.destinationClients):])
+	}
 
-		return r.indexAllAccounts(mergedAccounts, destinationIndex)
+	readerArgs := r.sourceReaderArgs
+	readerArgs.AfterID = readFromAddress
+	err := r.sourceIndexer.DoPointInTimeRequestAllDocuments(sourceIndex, crossIndex.GetAll().Bytes(), readerArgs, saverFunc)
+	errClose := writer.close()
+	cp.FailedDestinations = writer.failedPositions()
+	if err != nil {
+		return err
+	}
+	if errClose != nil {
+		return fmt.Errorf("%w, failed destinations: %v", errClose, describeFailedDestinations(report.Destinations, cp.FailedDestinations))
 	}
 
-	err = r.sourceIndexer.DoScrollRequestAllDocuments(sourceIndex, crossIndex.GetAll().Bytes(), saverFunc)
+	healthy := r.withoutFailedDestinations(cp.FailedDestinations, report)
+
+	err = healthy.checkAndCreateIndex(valuesIndex)
 	if err != nil {
 		return err
 	}
 
-	err = r.checkAndCreateValuesIndex()
+	// the extra information of the epoch is published only for a snapshot that passed the sanity gate
+	isSnapshotHealthy, err := healthy.checkSnapshot(destinationIndex, restAccounts.Epoch, report)
 	if err != nil {
 		return err
 	}
+	if isSnapshotHealthy {
+		err = healthy.indexExtraInformation(restAccounts)
+		if err != nil {
+			return err
+		}
+
+		err = healthy.moveAccountsAlias(destinationIndex)
+		if err != nil {
+			return err
+		}
+
+		err = healthy.indexStakeHistory(destinationIndex, restAccounts)
+		if err != nil {
+			return err
+		}
+	}
+
+	return r.finishExporters(restAccounts.EnergyBlockInfo)
+}
+
+// withoutFailedDestinations returns a copy of the reindexer that writes only in the destinations that did not fail.
+// The failed destinations are reported, so they can be re-filled later
+func (r *reindexer) withoutFailedDestinations(failedPositions []int, report *data.RunReport) *reindexer {
+	if len(failedPositions) == 0 {
+		return r
+	}
+
+	message := "some destination clusters missed accounts and have to be re-filled, the run continues with the others"
+	failedDestinations := describeFailedDestinations(report.Destinations, failedPositions)
+	log.Error(message, "write policy", r.writePolicy.mode, "failed destinations", failedDestinations)
+	report.AddWarning(message, "write policy", r.writePolicy.mode, "failed destinations", failedDestinations)
+
+	failed := make(map[int]struct{}, len(failedPositions))
+	for _, position := range failedPositions {
+		failed[position] = struct{}{}
+	}
+
+	healthyClients := make([]crossIndex.ElasticClientHandler, 0, len(r.destinationClients))
+	healthyIndexers := make([]crossIndex.AccountsIndexerHandler, 0, len(r.destinationIndexers))
+	for position, dstClient := range r.destinationClients {
+		if _, isFailed := failed[position]; !isFailed {
+			healthyClients = append(healthyClients, dstClient)
+			healthyIndexers = append(healthyIndexers, r.destinationIndexers[position])
+		}
+	}
+
+	healthy := *r
+	healthy.destinationClients = healthyClients
+	healthy.destinationIndexers = healthyIndexers
+
+	return &healthy
+}
+
+// prepareDestinationIndex will create the destination index on every destination cluster and will return the
+// checkpoint the reindexing should start from. When the write policy allows it, a destination that cannot be prepared
+// is marked as failed instead of failing the run
+func (r *reindexer) prepareDestinationIndex(destinationIndex string, epoch uint32, report *data.RunReport) (*checkpoint, error) {
+	template, policy, err := readTemplateAndPolicyForAccountsIndex(r.pathToIndicesConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	templateBytes, err := r.lifecyclePolicy.addToTemplate(template.Bytes(), crossIndex.AccountsPolicyName)
+	if err != nil {
+		return nil, err
+	}
+
+	policyBytes := policy.Bytes()
+
+	cp, err := r.checkpoints.load()
+	if err != nil {
+		return nil, err
+	}
+
+	canResume := cp != nil && cp.Index == destinationIndex && !cp.Done
+	if !canResume {
+		cp = &checkpoint{
+			Epoch: epoch,
+			Index: destinationIndex,
+		}
+	}
+
+	destinationReports := report.Destinations
+	failed := make(map[int]struct{}, len(cp.FailedDestinations))
+	for _, position := range cp.FailedDestinations {
+		failed[position] = struct{}{}
+		destinationReports[position].Status = data.DestinationStatusFailed
+		destinationReports[position].Error = "failed during a previous attempt"
+	}
+
+	for position, dstClient := range r.destinationClients {
+		if _, isFailed := failed[position]; isFailed {
+			continue
+		}
+
+		err = r.prepareDestinationCluster(dstClient, destinationIndex, templateBytes, policyBytes, cp, canResume, report)
+		if err == nil {
+			continue
+		}
+		if !r.writePolicy.allowsFailures() {
+			return nil, err
+		}
+
+		log.Error("cannot prepare the destination index", "destination", destinationReports[position].Name, "error", err)
+		report.AddWarning("cannot prepare the destination index", "destination", destinationReports[position].Name, "error", err)
+		cp.FailedDestinations = append(cp.FailedDestinations, position)
+		destinationReports[position].Status = data.DestinationStatusFailed
+		destinationReports[position].Error = err.Error()
+	}
+
+	err = r.writePolicy.checkFailures(len(cp.FailedDestinations))
+	if err != nil {
+		return nil, err
+	}
+
+	if canResume && cp.LastAddress != "" {
+		log.Info("resuming reindexing from checkpoint", "index", destinationIndex, "bulk", cp.NumBulks, "last address", cp.LastAddress)
+	}
+
+	return cp, r.checkpoints.save(cp)
+}
+
+func (r *reindexer) prepareDestinationCluster(
+	dstClient crossIndex.ElasticClientHandler,
+	destinationIndex string,
+	templateBytes []byte,
+	policyBytes []byte,
+	cp *checkpoint,
+	canResume bool,
+	report *data.RunReport,
+) error {
+	err := r.lifecyclePolicy.put(dstClient, crossIndex.AccountsPolicyName, policyBytes)
+	if err != nil {
+		return err
+	}
+
+	err = r.prepareStakeHistoryIndex(dstClient)
+	if err != nil {
+		return err
+	}
+
+	if canResume {
+		exists, errC := dstClient.CheckIfIndexExists(destinationIndex)
+		if errC != nil {
+			return errC
+		}
+		if exists {
+			return nil
+		}
+
+		message := "cannot find the index of the checkpoint on a destination cluster, the reindexing will start from the beginning"
+		log.Warn(message, "index", destinationIndex)
+		report.AddWarning(message, "index", destinationIndex)
+		cp.NumBulks = 0
+		cp.LastAddress = ""
+	}
+
+	log.Info("Create a new index with mapping", "index", destinationIndex)
+
+	return dstClient.CreateIndexWithMapping(destinationIndex, bytes.NewBuffer(templateBytes))
+}
+
+// WasReindexed returns true if the provided index was fully indexed on enough destination clusters for the write
+// policy and the accounts of the epoch were exported by all the exporters. When an accounts alias is configured, an
+// index is considered complete only after the alias was moved to it, or after the sanity gate marked it as unhealthy.
+// An index aborted by the sanity gate is considered handled as well, so that the daemon does not build it again on
+// every poll. It is built again by a new run of the manager, once the drift was checked
+func (r *reindexer) WasReindexed(destinationIndex string, epoch uint32) (bool, error) {
+	cp, err := r.checkpoints.load()
+	if err != nil {
+		return false, err
+	}
+	isPending := cp != nil && cp.Index == destinationIndex && !cp.Done
+
+	numFailed := 0
+	for _, dstClient := range r.destinationClients {
+		done, errW := r.wasReindexedOnCluster(dstClient, destinationIndex, epoch, isPending)
+		if errW != nil && !r.writePolicy.allowsFailures() {
+			return false, errW
+		}
+		if errW != nil {
+			log.Warn("cannot check if the index was reindexed on a destination cluster", "index", destinationIndex, "error", errW)
+		}
+		if errW != nil || !done {
+			numFailed++
+		}
+	}
+	if !r.writePolicy.canSucceed(numFailed) {
+		return false, nil
+	}
+
+	for _, exporter := range r.exporters {
+		done, errE := exporter.IsExported(epoch)
+		if errE != nil || !done {
+			return false, errE
+		}
+	}
+
+	return true, nil
+}
+
+func (r *reindexer) wasReindexedOnCluster(
+	dstClient crossIndex.ElasticClientHandler,
+	destinationIndex string,
+	epoch uint32,
+	isPending bool,
+) (bool, error) {
+	status, err := getSnapshotStatus(dstClient, destinationIndex, epoch)
+	if err != nil {
+		return false, err
+	}
+	if status == snapshotStatusAborted {
+		log.Warn("the sanity gate aborted the accounts index, it is not built again until the manager is run again",
+			"index", destinationIndex, "epoch", epoch)
+		return true, nil
+	}
+	if isPending {
+		return false, nil
+	}
+	if r.accountsAlias == "" {
+		return dstClient.CheckIfIndexExists(destinationIndex)
+	}
+
+	aliasIndices, err := dstClient.GetAliasIndices(r.accountsAlias)
+	if err != nil {
+		return false, err
+	}
+
+	for _, index := range aliasIndices {
+		if index == destinationIndex {
+			return true, nil
+		}
+	}
+
+	return status == snapshotStatusUnhealthy, nil
+}
+
+func (r *reindexer) startExporters(epoch uint32) error {
+	for _, exporter := range r.exporters {
+		err := exporter.Start(epoch)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *reindexer) exportAllAccounts(
+	mapAllAccounts map[string]*data.AccountInfoWithStakeValues,
+	exporterReports []*data.DestinationReport,
+) error {
+	for idx, exporter := range r.exporters {
+		err := exporter.Export(mapAllAccounts)
+		if err != nil {
+			return err
+		}
+
+		exporterReports[idx].NumIndexedAccounts += len(mapAllAccounts)
+	}
+
+	return nil
+}
+
+// createDestinationReports returns a report for every destination cluster, followed by a report for every exporter
+func (r *reindexer) createDestinationReports() []*data.DestinationReport {
+	reports := make([]*data.DestinationReport, 0, len(r.destinationClients)+len(r.exporters))
+	for idx := range r.destinationClients {
+		reports = append(reports, &data.DestinationReport{Name: fmt.Sprintf("cluster-%d", idx), Status: data.DestinationStatusOK})
+	}
+	for idx := range r.exporters {
+		reports = append(reports, &data.DestinationReport{Name: fmt.Sprintf("export-%d", idx), Status: data.DestinationStatusOK})
+	}
 
-	return r.indexExtraInformation(restAccounts)
+	return reports
 }
 
-func (r *reindexer) indexAllAccounts(mapAllAccounts map[string]*data.AccountInfoWithStakeValues, destinationIndex string) error {
+// IndexRunReport will save the provided run report in the runs index of every destination cluster. Every attempt is
+// saved in its own document, identified by epoch and start time, so a retry does not overwrite the failed attempt
+func (r *reindexer) IndexRunReport(report *data.RunReport) error {
+	if len(r.destinationClients) == 0 {
+		return nil
+	}
+
+	reportBytes, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+
+	err = r.checkAndCreateIndex(runsIndex)
+	if err != nil {
+		return err
+	}
+
+	id := runReportID(report)
 	for _, dstClient := range r.destinationClients {
-		acIndexer, err := accountsIndexer.NewAccountsIndexer(dstClient)
+		err = dstClient.DoRequest(runsIndex, id, bytes.NewBuffer(reportBytes))
 		if err != nil {
 			return err
 		}
+	}
+
+	return nil
+}
 
-		err = acIndexer.IndexAccounts(mapAllAccounts, destinationIndex)
+func (r *reindexer) finishExporters(blockInfo *data.BlockInfo) error {
+	for _, exporter := range r.exporters {
+		err := exporter.Finish(blockInfo)
 		if err != nil {
 			return err
 		}
 	}
+
 	return nil
 }
 
+func (r *reindexer) abortExporters() {
+	for _, exporter := range r.exporters {
+		exporter.Abort()
+	}
+}
+
 func (r *reindexer) indexExtraInformation(accountsData *data.AccountsData) error {
+	networkTotals := core.ComputeNetworkTotals(accountsData)
 	for _, dstClient := range r.destinationClients {
 		err := indexEnergyBlockInfo(accountsData.EnergyBlockInfo, accountsData.Epoch, dstClient)
 		if err != nil {
 			return err
 		}
+
+		err = indexSnapshotBlock(accountsData.SnapshotBlock, accountsData.Epoch, dstClient)
+		if err != nil {
+			return err
+		}
+
+		err = indexNetworkTotals(networkTotals, dstClient)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// moveAccountsAlias will point the accounts alias to the new index on every destination cluster. On each cluster the
+// alias is moved with a single `_aliases` request, so readers will see either the old index or the new one
+func (r *reindexer) moveAccountsAlias(newIndex string) error {
+	if r.accountsAlias == "" {
+		return nil
+	}
+
+	for _, dstClient := range r.destinationClients {
+		oldIndices, err := dstClient.GetAliasIndices(r.accountsAlias)
+		if err != nil {
+			return err
+		}
+
+		err = dstClient.UpdateAliases(r.accountsAlias, oldIndices, newIndex)
+		if err != nil {
+			return err
+		}
+
+		log.Info("moved accounts alias", "alias", r.accountsAlias, "from", oldIndices, "to", newIndex)
 	}
 
 	return nil
 }
 
 func indexEnergyBlockInfo(energyBlockInfo *data.BlockInfo, epoch uint32, esClient crossIndex.ElasticClientHandler) error {
+	if energyBlockInfo == nil {
+		return nil
+	}
+
 	log.Info(fmt.Sprintf("Indexing extra information in `%s` index...", valuesIndex))
 
 	id := fmt.Sprintf("energy-snapshot-%d", epoch)
@@ -136,12 +612,61 @@ func indexEnergyBlockInfo(energyBlockInfo *data.BlockInfo, epoch uint32, esClien
 	return esClient.DoRequest(valuesIndex, id, bytes.NewBuffer(keyValueObjBytes))
 }
 
-func (r *reindexer) checkAndCreateValuesIndex() error {
-	template, err := readTemplateForIndex(r.pathToIndicesConfig, valuesIndex)
+// indexSnapshotBlock will save the blocks at which the stake sources were read, so the snapshot can be reproduced
+func indexSnapshotBlock(snapshotBlock *data.SnapshotBlock, epoch uint32, esClient crossIndex.ElasticClientHandler) error {
+	if snapshotBlock == nil {
+		return nil
+	}
+
+	snapshotBlockBytes, err := json.Marshal(snapshotBlock)
+	if err != nil {
+		return err
+	}
+
+	id := fmt.Sprintf("snapshot-block-%d", epoch)
+	keyValueObj := &data.KeyValueObj{
+		Key:   "snapshotBlock",
+		Value: string(snapshotBlockBytes),
+	}
+
+	keyValueObjBytes, err := json.Marshal(keyValueObj)
+	if err != nil {
+		return err
+	}
+
+	return esClient.DoRequest(valuesIndex, id, bytes.NewBuffer(keyValueObjBytes))
+}
+
+// indexNetworkTotals will save the network-wide totals of the epoch, so they can be read without aggregating the accounts
+func indexNetworkTotals(networkTotals *data.NetworkTotals, esClient crossIndex.ElasticClientHandler) error {
+	networkTotalsBytes, err := json.Marshal(networkTotals)
+	if err != nil {
+		return err
+	}
+
+	id := fmt.Sprintf("network-totals-%d", networkTotals.Epoch)
+	keyValueObj := &data.KeyValueObj{
+		Key:   "networkTotals",
+		Value: string(networkTotalsBytes),
+	}
+
+	keyValueObjBytes, err := json.Marshal(keyValueObj)
+	if err != nil {
+		return err
+	}
+
+	return esClient.DoRequest(valuesIndex, id, bytes.NewBuffer(keyValueObjBytes))
+}
+
+func (r *reindexer) checkAndCreateIndex(index string) error {
+	template, err := readTemplateForIndex(r.pathToIndicesConfig, index)
+	if err != nil {
+		return err
+	}
 	templateBytes := template.Bytes()
 
 	for _, dstClient := range r.destinationClients {
-		exists, errC := dstClient.CheckIfIndexExists(valuesIndex)
+		exists, errC := dstClient.CheckIfIndexExists(index)
 		if errC != nil {
 			return errC
 		}
@@ -149,7 +674,7 @@ func (r *reindexer) checkAndCreateValuesIndex() error {
 			continue
 		}
 
-		err = dstClient.CreateIndexWithMapping(valuesIndex, bytes.NewBuffer(templateBytes))
+		err = dstClient.CreateIndexWithMapping(index, bytes.NewBuffer(templateBytes))
 		if err != nil {
 			return err
 		}
@@ -158,6 +683,16 @@ func (r *reindexer) checkAndCreateValuesIndex() error {
 	return nil
 }
 
+// getLastAddress returns the address of the last account from a response sorted by _id, which holds the address
+func getLastAddress(responseBytes []byte, defaultAddress string) string {
+	numHits := gjson.GetBytes(responseBytes, "hits.hits.#").Int()
+	if numHits == 0 {
+		return defaultAddress
+	}
+
+	return gjson.GetBytes(responseBytes, fmt.Sprintf("hits.hits.%d._id", numHits-1)).String()
+}
+
 func getAllAccounts(responseBytes []byte) (map[string]*data.AccountInfoWithStakeValues, error) {
 	accountsResponse := &crossIndex.AllAccountsResponse{}
 	err := json.Unmarshal(responseBytes, &accountsResponse)
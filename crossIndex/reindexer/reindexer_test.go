@@ -0,0 +1,69 @@
+package reindexer
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/mocks"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetLastAddress(t *testing.T) {
+	t.Parallel()
+
+	response := []byte(`{"hits":{"hits":[{"_id":"erd1a","_source":{"address":"erd1a"}},{"_id":"erd1b","_source":{"address":"erd1b"}}]}}`)
+	require.Equal(t, "erd1b", getLastAddress(response, "erd1"))
+
+	response = []byte(`{"hits":{"hits":[]}}`)
+	require.Equal(t, "erd1", getLastAddress(response, "erd1"))
+}
+
+func TestReindexer_IndexRunReport(t *testing.T) {
+	t.Parallel()
+
+	savedIDs := make([]string, 0)
+	savedReport := &data.RunReport{}
+	createdIndices := make([]string, 0)
+	esClient := &mocks.ElasticClientStub{
+		CheckIfIndexExistsCalled: func(index string) (bool, error) {
+			return len(createdIndices) > 0, nil
+		},
+		CreateIndexWithMappingCalled: func(targetIndex string, body *bytes.Buffer) error {
+			createdIndices = append(createdIndices, targetIndex)
+			require.Equal(t, "false", gjson.GetBytes(body.Bytes(), "mappings.properties.warnings.index").String())
+			return nil
+		},
+		DoRequestCalled: func(index string, documentID string, buff *bytes.Buffer) error {
+			require.Equal(t, runsIndex, index)
+			savedIDs = append(savedIDs, documentID)
+			return json.Unmarshal(buff.Bytes(), savedReport)
+		},
+	}
+	r := &reindexer{
+		destinationClients:  []crossIndex.ElasticClientHandler{esClient, esClient},
+		pathToIndicesConfig: pathToIndicesConfig,
+	}
+
+	startTime := time.Unix(0, 1000)
+	report := &data.RunReport{Epoch: 12, StartTime: startTime, Status: data.RunStatusFailure, Warnings: []string{"WARN something"}}
+	report.Destinations = r.createDestinationReports()
+	require.Equal(t, "cluster-1", report.Destinations[1].Name)
+
+	err := r.IndexRunReport(report)
+	require.Nil(t, err)
+	require.Equal(t, []string{runsIndex}, createdIndices)
+	require.Equal(t, []string{"12_1000", "12_1000"}, savedIDs)
+	require.Equal(t, report.Warnings, savedReport.Warnings)
+	require.Equal(t, data.RunStatusFailure, savedReport.Status)
+
+	retryReport := &data.RunReport{Epoch: 12, StartTime: startTime.Add(time.Minute), Status: data.RunStatusSuccess}
+	err = r.IndexRunReport(retryReport)
+	require.Nil(t, err)
+	require.Equal(t, "12_60000001000", savedIDs[2])
+	require.NotEqual(t, savedIDs[0], savedIDs[2])
+}
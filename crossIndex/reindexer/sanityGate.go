@@ -0,0 +1,371 @@
+package reindexer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/metrics"
+	"github.com/tidwall/gjson"
+)
+
+const (
+	// SanityGateActionAbort fails the run when the new snapshot drifted too much
+	SanityGateActionAbort = "abort"
+	// SanityGateActionMarkUnhealthy keeps the new index, but does not move the accounts alias to it
+	SanityGateActionMarkUnhealthy = "mark-unhealthy"
+
+	snapshotStatusHealthy   = "healthy"
+	snapshotStatusUnhealthy = "unhealthy"
+	snapshotStatusAborted   = "aborted"
+
+	totalAccountsDriftName = "accounts"
+	sourceAccountsPrefix   = "accounts-"
+)
+
+type snapshotDrift struct {
+	Name            string  `json:"name"`
+	Previous        float64 `json:"previous"`
+	Current         float64 `json:"current"`
+	DriftPercent    float64 `json:"driftPercent"`
+	MaxDriftPercent float64 `json:"maxDriftPercent"`
+}
+
+// snapshotHealth holds the result of the sanity gate. It is saved in the values index
+type snapshotHealth struct {
+	Epoch         uint32           `json:"epoch"`
+	Index         string           `json:"index"`
+	PreviousIndex string           `json:"previousIndex"`
+	Status        string           `json:"status"`
+	Drifts        []*snapshotDrift `json:"drifts,omitempty"`
+}
+
+type sanityGate struct {
+	action         string
+	maxDrifts      map[string]float64
+	maxSourceDrift float64
+}
+
+// newSanityGate will create a new sanityGate. A nil gate is returned if the gate is disabled
+func newSanityGate(cfg config.SanityGateConfig) (*sanityGate, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	if cfg.Action != SanityGateActionAbort && cfg.Action != SanityGateActionMarkUnhealthy {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidSanityGateAction, cfg.Action)
+	}
+
+	maxDrifts := map[string]float64{
+		"totalStake":           cfg.MaxStakeDriftPercent,
+		"totalUnDelegated":     cfg.MaxUnDelegatedDriftPercent,
+		"energy":               cfg.MaxEnergyDriftPercent,
+		"lkMexStake":           cfg.MaxLKMEXDriftPercent,
+		totalAccountsDriftName: cfg.MaxAccountsDriftPercent,
+	}
+	for name, maxDrift := range maxDrifts {
+		if maxDrift < 0 {
+			return nil, fmt.Errorf("%w for %s: %v", ErrInvalidSanityGateThreshold, name, maxDrift)
+		}
+	}
+
+	return &sanityGate{
+		action:         cfg.Action,
+		maxDrifts:      maxDrifts,
+		maxSourceDrift: cfg.MaxAccountsDriftPercent,
+	}, nil
+}
+
+// check returns the drifts of the current snapshot that passed the configured thresholds
+func (sg *sanityGate) check(previous *crossIndex.SnapshotStats, current *crossIndex.SnapshotStats) []*snapshotDrift {
+	drifts := make([]*snapshotDrift, 0)
+	addDrift := func(name string, previousValue float64, currentValue float64, maxDrift float64) {
+		if maxDrift == 0 {
+			return
+		}
+
+		driftPercent := computeDriftPercent(previousValue, currentValue)
+		if driftPercent <= maxDrift {
+			return
+		}
+
+		drifts = append(drifts, &snapshotDrift{
+			Name:            name,
+			Previous:        previousValue,
+			Current:         currentValue,
+			DriftPercent:    driftPercent,
+			MaxDriftPercent: maxDrift,
+		})
+	}
+
+	for _, name := range sortedSumAggregations() {
+		addDrift(name, previous.Sums[name], current.Sums[name], sg.maxDrifts[name])
+	}
+	addDrift(totalAccountsDriftName, previous.NumAccounts, current.NumAccounts, sg.maxDrifts[totalAccountsDriftName])
+	for _, source := range sortedSources() {
+		addDrift(sourceAccountsPrefix+source, previous.SourceAccounts[source], current.SourceAccounts[source], sg.maxSourceDrift)
+	}
+
+	return drifts
+}
+
+// checkSnapshot will compare the new accounts index with the previous one on the first destination cluster. It returns
+// false if the new index should not be published. The drifts of an unhealthy index are saved in the provided run report
+func (r *reindexer) checkSnapshot(destinationIndex string, epoch uint32, report *data.RunReport) (bool, error) {
+	if r.sanityGate == nil || len(r.destinationClients) == 0 {
+		return true, nil
+	}
+
+	gateClient := r.destinationClients[0]
+	previousIndex, err := r.getPreviousIndex(gateClient, destinationIndex, epoch)
+	if err != nil {
+		return false, err
+	}
+	if previousIndex == "" {
+		log.Info("cannot find a previous accounts index, the sanity gate is skipped", "index", destinationIndex)
+		return true, nil
+	}
+
+	err = gateClient.RefreshIndex(destinationIndex)
+	if err != nil {
+		return false, err
+	}
+
+	previousStats, err := getSnapshotStats(gateClient, previousIndex)
+	if err != nil {
+		return false, err
+	}
+	currentStats, err := getSnapshotStats(gateClient, destinationIndex)
+	if err != nil {
+		return false, err
+	}
+
+	health := &snapshotHealth{
+		Epoch:         epoch,
+		Index:         destinationIndex,
+		PreviousIndex: previousIndex,
+		Status:        snapshotStatusHealthy,
+		Drifts:        r.sanityGate.check(previousStats, currentStats),
+	}
+	if len(health.Drifts) > 0 {
+		health.Status = snapshotStatusUnhealthy
+		if r.sanityGate.action == SanityGateActionAbort {
+			health.Status = snapshotStatusAborted
+		}
+	}
+	metrics.SetSnapshotHealthy(len(health.Drifts) == 0)
+
+	err = r.indexSnapshotHealth(health)
+	if err != nil {
+		return false, err
+	}
+
+	if len(health.Drifts) == 0 {
+		log.Info("the new accounts snapshot passed the sanity gate", "index", destinationIndex, "previous index", previousIndex)
+		return true, nil
+	}
+
+	description := describeDrifts(health.Drifts)
+	if r.sanityGate.action == SanityGateActionAbort {
+		r.deleteAbortedIndex(destinationIndex, report)
+		return false, fmt.Errorf("%w, index %s, previous index %s: %s", ErrSnapshotDrift, destinationIndex, previousIndex, description)
+	}
+
+	message := "the new accounts snapshot was marked as unhealthy, the accounts alias is not moved"
+	log.Error(message, "index", destinationIndex, "previous index", previousIndex, "drifts", description)
+	report.AddWarning(message, "index", destinationIndex, "previous index", previousIndex, "drifts", description)
+
+	return false, nil
+}
+
+// getPreviousIndex returns the index the accounts alias points to, or the index of the previous epoch if there is no
+// alias. An empty string is returned if there is no previous index
+func (r *reindexer) getPreviousIndex(esClient crossIndex.ElasticClientHandler, destinationIndex string, epoch uint32) (string, error) {
+	if r.accountsAlias != "" {
+		aliasIndices, err := esClient.GetAliasIndices(r.accountsAlias)
+		if err != nil {
+			return "", err
+		}
+
+		sortIndicesByEpoch(aliasIndices)
+		for idx := len(aliasIndices) - 1; idx >= 0; idx-- {
+			if aliasIndices[idx] != destinationIndex {
+				return aliasIndices[idx], nil
+			}
+		}
+	}
+
+	epochSuffix := fmt.Sprintf("_%d", epoch)
+	if epoch == 0 || !strings.HasSuffix(destinationIndex, epochSuffix) {
+		return "", nil
+	}
+
+	previousIndex := fmt.Sprintf("%s_%d", strings.TrimSuffix(destinationIndex, epochSuffix), epoch-1)
+	exists, err := esClient.CheckIfIndexExists(previousIndex)
+	if err != nil || !exists {
+		return "", err
+	}
+
+	return previousIndex, nil
+}
+
+// sortIndicesByEpoch sorts the provided indices by the epoch from their `_<epoch>` suffix, compared as a number. The
+// indices without an epoch suffix come first
+func sortIndicesByEpoch(indices []string) {
+	sort.SliceStable(indices, func(i, j int) bool {
+		epochI, hasEpochI := getIndexEpoch(indices[i])
+		epochJ, hasEpochJ := getIndexEpoch(indices[j])
+		if hasEpochI != hasEpochJ {
+			return hasEpochJ
+		}
+
+		return epochI < epochJ
+	})
+}
+
+// getIndexEpoch returns the epoch from the `_<epoch>` suffix of the provided index name
+func getIndexEpoch(index string) (uint32, bool) {
+	separatorPosition := strings.LastIndex(index, "_")
+	if separatorPosition < 0 {
+		return 0, false
+	}
+
+	epoch, err := strconv.ParseUint(index[separatorPosition+1:], 10, 32)
+	if err != nil {
+		return 0, false
+	}
+
+	return uint32(epoch), true
+}
+
+func (r *reindexer) indexSnapshotHealth(health *snapshotHealth) error {
+	healthBytes, err := json.Marshal(health)
+	if err != nil {
+		return err
+	}
+
+	keyValueObj := &data.KeyValueObj{
+		Key:   "snapshotHealth",
+		Value: string(healthBytes),
+	}
+	keyValueObjBytes, err := json.Marshal(keyValueObj)
+	if err != nil {
+		return err
+	}
+
+	for _, dstClient := range r.destinationClients {
+		err = dstClient.DoRequest(valuesIndex, snapshotHealthID(health.Epoch), bytes.NewBuffer(keyValueObjBytes))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteAbortedIndex will delete the aborted index when there is no accounts alias, as without an alias the index is
+// published as soon as it exists. With an alias, the index is kept for inspection and the alias is not moved
+func (r *reindexer) deleteAbortedIndex(destinationIndex string, report *data.RunReport) {
+	if r.accountsAlias != "" {
+		return
+	}
+
+	for _, dstClient := range r.destinationClients {
+		err := dstClient.DeleteIndex(destinationIndex)
+		if err != nil {
+			log.Error("cannot delete the aborted accounts index", "index", destinationIndex, "error", err)
+			report.AddWarning("cannot delete the aborted accounts index", "index", destinationIndex, "error", err)
+		}
+	}
+}
+
+// getSnapshotStatus returns the status saved by the sanity gate for the provided index, or an empty string if the
+// index was not checked
+func getSnapshotStatus(esClient crossIndex.ElasticClientHandler, destinationIndex string, epoch uint32) (string, error) {
+	exists, err := esClient.CheckIfIndexExists(valuesIndex)
+	if err != nil || !exists {
+		return "", err
+	}
+
+	responseBytes, err := esClient.DoMultiGet([]string{snapshotHealthID(epoch)}, valuesIndex)
+	if err != nil {
+		return "", err
+	}
+
+	healthValue := gjson.GetBytes(responseBytes, "docs.0._source.value")
+	if !healthValue.Exists() {
+		return "", nil
+	}
+
+	health := &snapshotHealth{}
+	err = json.Unmarshal([]byte(healthValue.String()), health)
+	if err != nil {
+		return "", err
+	}
+	if health.Index != destinationIndex {
+		return "", nil
+	}
+
+	return health.Status, nil
+}
+
+func getSnapshotStats(esClient crossIndex.ElasticClientHandler, index string) (*crossIndex.SnapshotStats, error) {
+	responseBytes, err := esClient.DoSearchRequest(index, crossIndex.GetSnapshotStats().Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	return crossIndex.ParseSnapshotStats(responseBytes)
+}
+
+func computeDriftPercent(previous float64, current float64) float64 {
+	if previous == current {
+		return 0
+	}
+	if previous == 0 {
+		// any value that appeared from nothing is reported as a full drift
+		return 100
+	}
+
+	return math.Abs(current-previous) / math.Abs(previous) * 100
+}
+
+func describeDrifts(drifts []*snapshotDrift) string {
+	descriptions := make([]string, 0, len(drifts))
+	for _, drift := range drifts {
+		descriptions = append(descriptions, fmt.Sprintf("%s from %v to %v (%.2f%% > %v%%)",
+			drift.Name, drift.Previous, drift.Current, drift.DriftPercent, drift.MaxDriftPercent))
+	}
+
+	return strings.Join(descriptions, ", ")
+}
+
+func snapshotHealthID(epoch uint32) string {
+	return fmt.Sprintf("snapshot-health-%d", epoch)
+}
+
+func sortedSumAggregations() []string {
+	names := make([]string, 0, len(crossIndex.SnapshotSumAggregations))
+	for name := range crossIndex.SnapshotSumAggregations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func sortedSources() []string {
+	sources := make([]string, 0, len(crossIndex.SnapshotSourceFields))
+	for source := range crossIndex.SnapshotSourceFields {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+
+	return sources
+}
@@ -0,0 +1,245 @@
+package reindexer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func createSanityGateConfig(action string) config.SanityGateConfig {
+	return config.SanityGateConfig{
+		Enabled:                    true,
+		Action:                     action,
+		MaxStakeDriftPercent:       10,
+		MaxUnDelegatedDriftPercent: 50,
+		MaxEnergyDriftPercent:      25,
+		MaxLKMEXDriftPercent:       0,
+		MaxAccountsDriftPercent:    10,
+	}
+}
+
+func createStatsResponse(numAccounts int, totalStake float64, numDelegators int) []byte {
+	return []byte(fmt.Sprintf(`{"hits":{"total":{"value":%d}},"aggregations":{
+		"totalStake":{"value":%v},"totalUnDelegated":{"value":100},"energy":{"value":1000},"lkMexStake":{"value":%v},
+		"legacy-delegation":{"doc_count":10},"validators":{"doc_count":20},"delegators":{"doc_count":%d},
+		"lkmex":{"doc_count":5},"energy":{"doc_count":50}}}`, numAccounts, totalStake, totalStake, numDelegators))
+}
+
+func TestNewSanityGate(t *testing.T) {
+	t.Parallel()
+
+	gate, err := newSanityGate(config.SanityGateConfig{Action: "unknown"})
+	require.Nil(t, err)
+	require.Nil(t, gate)
+
+	_, err = newSanityGate(createSanityGateConfig("unknown"))
+	require.ErrorIs(t, err, ErrInvalidSanityGateAction)
+
+	cfg := createSanityGateConfig(SanityGateActionAbort)
+	cfg.MaxEnergyDriftPercent = -1
+	_, err = newSanityGate(cfg)
+	require.ErrorIs(t, err, ErrInvalidSanityGateThreshold)
+
+	gate, err = newSanityGate(createSanityGateConfig(SanityGateActionMarkUnhealthy))
+	require.Nil(t, err)
+	require.Equal(t, SanityGateActionMarkUnhealthy, gate.action)
+}
+
+func TestSanityGate_Check(t *testing.T) {
+	t.Parallel()
+
+	gate, _ := newSanityGate(createSanityGateConfig(SanityGateActionAbort))
+
+	previous, err := crossIndex.ParseSnapshotStats(createStatsResponse(1000, 100, 300))
+	require.Nil(t, err)
+
+	current, _ := crossIndex.ParseSnapshotStats(createStatsResponse(1050, 105, 290))
+	require.Empty(t, gate.check(previous, current))
+
+	// the LKMEX stake follows the total stake in the responses, but its check is disabled
+	current, _ = crossIndex.ParseSnapshotStats(createStatsResponse(1000, 80, 200))
+	drifts := gate.check(previous, current)
+	require.Len(t, drifts, 2)
+	require.Equal(t, "totalStake", drifts[0].Name)
+	require.Equal(t, float64(20), drifts[0].DriftPercent)
+	require.Equal(t, "accounts-delegators", drifts[1].Name)
+	require.Equal(t, float64(300), drifts[1].Previous)
+	require.Equal(t, float64(200), drifts[1].Current)
+}
+
+func TestComputeDriftPercent(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, float64(0), computeDriftPercent(0, 0))
+	require.Equal(t, float64(100), computeDriftPercent(0, 1))
+	require.Equal(t, float64(50), computeDriftPercent(200, 100))
+	require.Equal(t, float64(50), computeDriftPercent(200, 300))
+}
+
+func TestReindexer_CheckSnapshot(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no previous index should skip the gate", func(t *testing.T) {
+		t.Parallel()
+
+		esClient := &mocks.ElasticClientStub{
+			DoSearchRequestCalled: func(_ string, _ []byte) ([]byte, error) {
+				require.Fail(t, "should have not been called")
+				return nil, nil
+			},
+		}
+		gate, _ := newSanityGate(createSanityGateConfig(SanityGateActionAbort))
+		r := &reindexer{destinationClients: []crossIndex.ElasticClientHandler{esClient}, sanityGate: gate}
+
+		healthy, err := r.checkSnapshot("accounts-000001_10", 10, &data.RunReport{})
+		require.Nil(t, err)
+		require.True(t, healthy)
+	})
+
+	t.Run("drift with abort action should error", func(t *testing.T) {
+		t.Parallel()
+
+		savedHealth := &snapshotHealth{}
+		esClient := &mocks.ElasticClientStub{
+			GetAliasIndicesCalled: func(alias string) ([]string, error) {
+				return []string{"accounts-000001_8"}, nil
+			},
+			DoSearchRequestCalled: func(index string, _ []byte) ([]byte, error) {
+				if index == "accounts-000001_8" {
+					return createStatsResponse(1000, 100, 300), nil
+				}
+				return createStatsResponse(1000, 100, 200), nil
+			},
+			DoRequestCalled: func(index string, documentID string, buff *bytes.Buffer) error {
+				require.Equal(t, valuesIndex, index)
+				require.Equal(t, "snapshot-health-10", documentID)
+
+				keyValue := &data.KeyValueObj{}
+				_ = json.Unmarshal(buff.Bytes(), keyValue)
+				return json.Unmarshal([]byte(keyValue.Value), savedHealth)
+			},
+		}
+		esClient.DeleteIndexCalled = func(_ string) error {
+			require.Fail(t, "should have not been called")
+			return nil
+		}
+		gate, _ := newSanityGate(createSanityGateConfig(SanityGateActionAbort))
+		r := &reindexer{destinationClients: []crossIndex.ElasticClientHandler{esClient}, sanityGate: gate, accountsAlias: "accounts"}
+
+		healthy, err := r.checkSnapshot("accounts-000001_10", 10, &data.RunReport{})
+		require.ErrorIs(t, err, ErrSnapshotDrift)
+		require.False(t, healthy)
+		require.Equal(t, snapshotStatusAborted, savedHealth.Status)
+		require.Equal(t, "accounts-000001_8", savedHealth.PreviousIndex)
+		require.Len(t, savedHealth.Drifts, 1)
+	})
+
+	t.Run("drift with abort action and no alias should delete the index", func(t *testing.T) {
+		t.Parallel()
+
+		savedHealth := []byte("")
+		deletedIndices := make([]string, 0)
+		esClient := &mocks.ElasticClientStub{
+			CheckIfIndexExistsCalled: func(index string) (bool, error) {
+				return true, nil
+			},
+			DoSearchRequestCalled: func(index string, _ []byte) ([]byte, error) {
+				if index == "accounts-000001_9" {
+					return createStatsResponse(1000, 100, 300), nil
+				}
+				return createStatsResponse(600, 100, 300), nil
+			},
+			DoRequestCalled: func(_ string, _ string, buff *bytes.Buffer) error {
+				savedHealth = buff.Bytes()
+				return nil
+			},
+			DoMultiGetCalled: func(ids []string, index string) ([]byte, error) {
+				return []byte(fmt.Sprintf(`{"docs":[{"found":true,"_source":%s}]}`, savedHealth)), nil
+			},
+			DeleteIndexCalled: func(index string) error {
+				deletedIndices = append(deletedIndices, index)
+				return nil
+			},
+		}
+		gate, _ := newSanityGate(createSanityGateConfig(SanityGateActionAbort))
+		r := &reindexer{destinationClients: []crossIndex.ElasticClientHandler{esClient}, sanityGate: gate}
+
+		healthy, err := r.checkSnapshot("accounts-000001_10", 10, &data.RunReport{})
+		require.ErrorIs(t, err, ErrSnapshotDrift)
+		require.False(t, healthy)
+		require.Equal(t, []string{"accounts-000001_10"}, deletedIndices)
+
+		// the aborted index is not built again by the daemon on every poll
+		done, err := r.wasReindexedOnCluster(esClient, "accounts-000001_10", 10, true)
+		require.Nil(t, err)
+		require.True(t, done)
+	})
+
+	t.Run("drift with mark unhealthy action should not publish", func(t *testing.T) {
+		t.Parallel()
+
+		savedHealth := []byte("")
+		esClient := &mocks.ElasticClientStub{
+			CheckIfIndexExistsCalled: func(index string) (bool, error) {
+				return true, nil
+			},
+			DoSearchRequestCalled: func(index string, _ []byte) ([]byte, error) {
+				if index == "accounts-000001_9" {
+					return createStatsResponse(1000, 100, 300), nil
+				}
+				return createStatsResponse(600, 100, 300), nil
+			},
+			DoRequestCalled: func(_ string, _ string, buff *bytes.Buffer) error {
+				savedHealth = buff.Bytes()
+				return nil
+			},
+			DoMultiGetCalled: func(ids []string, index string) ([]byte, error) {
+				return []byte(fmt.Sprintf(`{"docs":[{"found":true,"_source":%s}]}`, savedHealth)), nil
+			},
+		}
+		gate, _ := newSanityGate(createSanityGateConfig(SanityGateActionMarkUnhealthy))
+		r := &reindexer{destinationClients: []crossIndex.ElasticClientHandler{esClient}, sanityGate: gate}
+
+		report := &data.RunReport{}
+		healthy, err := r.checkSnapshot("accounts-000001_10", 10, report)
+		require.Nil(t, err)
+		require.False(t, healthy)
+		require.Len(t, report.Warnings, 1)
+		require.True(t, strings.HasPrefix(report.Warnings[0], "the new accounts snapshot was marked as unhealthy"))
+
+		status, err := getSnapshotStatus(esClient, "accounts-000001_10", 10)
+		require.Nil(t, err)
+		require.Equal(t, snapshotStatusUnhealthy, status)
+	})
+}
+
+func TestReindexer_GetPreviousIndexShouldCompareTheEpochsAsNumbers(t *testing.T) {
+	t.Parallel()
+
+	esClient := &mocks.ElasticClientStub{
+		GetAliasIndicesCalled: func(alias string) ([]string, error) {
+			return []string{"accounts-000001_1000", "accounts-000001_999", "accounts-000001_1001", "accounts-old"}, nil
+		},
+	}
+	r := &reindexer{accountsAlias: "accounts"}
+
+	previousIndex, err := r.getPreviousIndex(esClient, "accounts-000001_1001", 1001)
+	require.Nil(t, err)
+	require.Equal(t, "accounts-000001_1000", previousIndex)
+}
+
+func TestSortIndicesByEpoch(t *testing.T) {
+	t.Parallel()
+
+	indices := []string{"accounts-000001_1000", "accounts-000001_999", "accounts-old", "accounts-000001_9"}
+	sortIndicesByEpoch(indices)
+	require.Equal(t, []string{"accounts-old", "accounts-000001_9", "accounts-000001_999", "accounts-000001_1000"}, indices)
+}
@@ -0,0 +1,44 @@
+package reindexer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+)
+
+const (
+	defaultSourcePageSize           = 9000
+	maxSourcePageSize               = 10000
+	defaultSourceKeepAliveInSeconds = 300
+	defaultSourceMaxRetries         = 5
+)
+
+// newPointInTimeArgs will create the arguments used to read the source accounts index with a point in time. The zero
+// values of the config are replaced with defaults
+func newPointInTimeArgs(cfg config.SourceReaderConfig) (data.PointInTimeArgs, error) {
+	if cfg.PageSize < 0 || cfg.PageSize > maxSourcePageSize {
+		return data.PointInTimeArgs{}, fmt.Errorf("%w: page size %d, it should be at most %d", ErrInvalidSourceReaderConfig, cfg.PageSize, maxSourcePageSize)
+	}
+	if cfg.KeepAliveInSeconds < 0 || cfg.MaxRetries < 0 {
+		return data.PointInTimeArgs{}, fmt.Errorf("%w: negative values are not allowed", ErrInvalidSourceReaderConfig)
+	}
+
+	args := data.PointInTimeArgs{
+		PageSize:   cfg.PageSize,
+		KeepAlive:  time.Duration(cfg.KeepAliveInSeconds) * time.Second,
+		MaxRetries: cfg.MaxRetries,
+	}
+	if args.PageSize == 0 {
+		args.PageSize = defaultSourcePageSize
+	}
+	if args.KeepAlive == 0 {
+		args.KeepAlive = defaultSourceKeepAliveInSeconds * time.Second
+	}
+	if args.MaxRetries == 0 {
+		args.MaxRetries = defaultSourceMaxRetries
+	}
+
+	return args, nil
+}
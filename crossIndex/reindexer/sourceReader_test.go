@@ -0,0 +1,32 @@
+package reindexer
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPointInTimeArgs(t *testing.T) {
+	t.Parallel()
+
+	args, err := newPointInTimeArgs(config.SourceReaderConfig{})
+	require.Nil(t, err)
+	require.Equal(t, defaultSourcePageSize, args.PageSize)
+	require.Equal(t, 5*time.Minute, args.KeepAlive)
+	require.Equal(t, defaultSourceMaxRetries, args.MaxRetries)
+
+	args, err = newPointInTimeArgs(config.SourceReaderConfig{PageSize: 500, KeepAliveInSeconds: 60, MaxRetries: 1})
+	require.Nil(t, err)
+	require.Equal(t, 500, args.PageSize)
+	require.Equal(t, time.Minute, args.KeepAlive)
+	require.Equal(t, 1, args.MaxRetries)
+
+	_, err = newPointInTimeArgs(config.SourceReaderConfig{PageSize: maxSourcePageSize + 1})
+	require.True(t, errors.Is(err, ErrInvalidSourceReaderConfig))
+
+	_, err = newPointInTimeArgs(config.SourceReaderConfig{KeepAliveInSeconds: -1})
+	require.True(t, errors.Is(err, ErrInvalidSourceReaderConfig))
+}
@@ -0,0 +1,145 @@
+package reindexer
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"sort"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+)
+
+// numStakeHistoryAddressesInChunk is the number of accounts read from the new accounts index at once, in order to write
+// their stake history
+const numStakeHistoryAddressesInChunk = 10000
+
+// prepareStakeHistoryIndex will put the stake history policy and index template on the provided cluster and will create
+// the first stake history index, if the write alias does not exist yet
+func (r *reindexer) prepareStakeHistoryIndex(dstClient crossIndex.ElasticClientHandler) error {
+	if !r.stakeHistoryEnabled {
+		return nil
+	}
+
+	template, err := readFile(path.Join(r.pathToIndicesConfig, stakeHistoryTemplateFileName))
+	if err != nil {
+		return err
+	}
+	policy, err := readFile(path.Join(r.pathToIndicesConfig, stakeHistoryPolicyFileName))
+	if err != nil {
+		return err
+	}
+
+	err = r.lifecyclePolicy.put(dstClient, crossIndex.StakeHistoryPolicyName, policy.Bytes())
+	if err != nil {
+		return err
+	}
+
+	templateBytes, err := r.lifecyclePolicy.addToTemplate(template.Bytes(), crossIndex.StakeHistoryPolicyName)
+	if err != nil {
+		return err
+	}
+	templateBytes, err = r.lifecyclePolicy.addRolloverAliasToTemplate(templateBytes, crossIndex.StakeHistoryAlias)
+	if err != nil {
+		return err
+	}
+
+	err = dstClient.PutIndexTemplate(crossIndex.StakeHistoryAlias, bytes.NewBuffer(templateBytes))
+	if err != nil {
+		return err
+	}
+
+	exists, err := dstClient.CheckIfIndexExists(crossIndex.StakeHistoryAlias)
+	if err != nil || exists {
+		return err
+	}
+
+	firstIndex := fmt.Sprintf("%s-000001", crossIndex.StakeHistoryAlias)
+	log.Info("create the first stake history index", "index", firstIndex, "alias", crossIndex.StakeHistoryAlias)
+
+	body := fmt.Sprintf(`{"aliases":{"%s":{"is_write_index":true}}}`, crossIndex.StakeHistoryAlias)
+	return dstClient.CreateIndexWithMapping(firstIndex, bytes.NewBufferString(body))
+}
+
+// indexStakeHistory will write the stake history of the epoch on every destination cluster. It is called only for a
+// snapshot that was published, and the accounts with stake are read back from the new accounts index, so the history
+// is complete even when the reindexing was resumed from a checkpoint
+func (r *reindexer) indexStakeHistory(destinationIndex string, accountsData *data.AccountsData) error {
+	if !r.stakeHistoryEnabled {
+		return nil
+	}
+
+	addresses := make([]string, 0, len(accountsData.AccountsWithStake))
+	for address := range accountsData.AccountsWithStake {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	for idx, dstClient := range r.destinationClients {
+		err := indexStakeHistoryOnCluster(dstClient, r.destinationIndexers[idx], destinationIndex, addresses, accountsData)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func indexStakeHistoryOnCluster(
+	dstClient crossIndex.ElasticClientHandler,
+	indexer crossIndex.AccountsIndexerHandler,
+	destinationIndex string,
+	addresses []string,
+	accountsData *data.AccountsData,
+) error {
+	// the documents of the epoch are deleted from all the indices behind the alias first, as they are written in the
+	// current write index and an epoch written again after a rollover would otherwise be held twice
+	err := dstClient.DeleteByQuery(crossIndex.StakeHistoryAlias, crossIndex.GetByEpoch(accountsData.Epoch).Bytes())
+	if err != nil {
+		return err
+	}
+
+	numEntries := 0
+	for from := 0; from < len(addresses); from += numStakeHistoryAddressesInChunk {
+		to := from + numStakeHistoryAddressesInChunk
+		if to > len(addresses) {
+			to = len(addresses)
+		}
+
+		accounts, errGet := indexer.GetAccountsStrict(addresses[from:to], destinationIndex)
+		if errGet != nil {
+			return errGet
+		}
+
+		entries := createStakeHistoryEntries(accounts, accountsData)
+		err = indexer.IndexStakeHistory(entries, crossIndex.StakeHistoryAlias)
+		if err != nil {
+			return err
+		}
+		numEntries += len(entries)
+	}
+
+	log.Info("indexed stake history", "epoch", accountsData.Epoch, "index", destinationIndex, "documents", numEntries)
+
+	return nil
+}
+
+// createStakeHistoryEntries will create a stake history document for every merged account that has stake information
+func createStakeHistoryEntries(mergedAccounts map[string]*data.AccountInfoWithStakeValues, restAccounts *data.AccountsData) []*data.AccountStakeHistory {
+	entries := make([]*data.AccountStakeHistory, 0)
+	for address, account := range mergedAccounts {
+		if _, hasStake := restAccounts.AccountsWithStake[address]; !hasStake {
+			continue
+		}
+
+		entries = append(entries, &data.AccountStakeHistory{
+			Address:    address,
+			Epoch:      restAccounts.Epoch,
+			Balance:    account.Balance,
+			BalanceNum: account.BalanceNum,
+			StakeInfo:  account.StakeInfo,
+		})
+	}
+
+	return entries
+}
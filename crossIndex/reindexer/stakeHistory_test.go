@@ -0,0 +1,160 @@
+package reindexer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/mocks"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/process/accountsIndexer"
+	"github.com/stretchr/testify/require"
+)
+
+const pathToIndicesConfig = "../../cmd/manager/config/indices"
+
+func TestReindexer_IndexStakeHistory(t *testing.T) {
+	t.Parallel()
+
+	restAccounts := &data.AccountsData{
+		AccountsWithStake: map[string]*data.AccountInfoWithStakeValues{
+			"erd1a": {},
+		},
+		Epoch: 7,
+	}
+
+	calls := make([]string, 0)
+	bulkBody := ""
+	esClient := &mocks.ElasticClientStub{
+		DeleteByQueryCalled: func(index string, body []byte) error {
+			require.Equal(t, crossIndex.StakeHistoryAlias, index)
+			require.JSONEq(t, `{"query":{"term":{"epoch":7}}}`, string(body))
+			calls = append(calls, "delete")
+			return nil
+		},
+		DoMultiGetCalled: func(ids []string, index string) ([]byte, error) {
+			require.Equal(t, []string{"erd1a"}, ids)
+			require.Equal(t, "accounts-000001_7", index)
+			calls = append(calls, "get")
+			return []byte(`{"docs":[{"_id":"erd1a","found":true,"_source":{"balance":"5","totalStake":"10"}}]}`), nil
+		},
+		DoBulkRequestCalled: func(buff *bytes.Buffer, index string) error {
+			require.Equal(t, crossIndex.StakeHistoryAlias, index)
+			calls = append(calls, "index")
+			bulkBody += buff.String()
+			return nil
+		},
+	}
+	acIndexer, _ := accountsIndexer.NewAccountsIndexer(esClient, config.BulkIndexerConfig{})
+	r := &reindexer{
+		stakeHistoryEnabled: true,
+		destinationClients:  []crossIndex.ElasticClientHandler{esClient},
+		destinationIndexers: []crossIndex.AccountsIndexerHandler{acIndexer},
+	}
+
+	err := r.indexStakeHistory("accounts-000001_7", restAccounts)
+	require.Nil(t, err)
+	require.Equal(t, []string{"delete", "get", "index"}, calls)
+
+	lines := strings.Split(strings.TrimSpace(bulkBody), "\n")
+	require.Len(t, lines, 2)
+	require.Equal(t, `{ "index" : { "_id" : "erd1a_7" } }`, lines[0])
+
+	entry := &data.AccountStakeHistory{}
+	err = json.Unmarshal([]byte(lines[1]), entry)
+	require.Nil(t, err)
+	require.Equal(t, "erd1a", entry.Address)
+	require.Equal(t, uint32(7), entry.Epoch)
+	require.Equal(t, "5", entry.Balance)
+	require.Equal(t, "10", entry.TotalStake)
+}
+
+func TestReindexer_IndexStakeHistoryShouldNotWriteWhenTheDeleteFails(t *testing.T) {
+	t.Parallel()
+
+	esClient := &mocks.ElasticClientStub{
+		DeleteByQueryCalled: func(_ string, _ []byte) error {
+			return errors.New("local error")
+		},
+		DoBulkRequestCalled: func(_ *bytes.Buffer, _ string) error {
+			require.Fail(t, "the stake history should not be written")
+			return nil
+		},
+	}
+	acIndexer, _ := accountsIndexer.NewAccountsIndexer(esClient, config.BulkIndexerConfig{})
+	r := &reindexer{
+		stakeHistoryEnabled: true,
+		destinationClients:  []crossIndex.ElasticClientHandler{esClient},
+		destinationIndexers: []crossIndex.AccountsIndexerHandler{acIndexer},
+	}
+
+	restAccounts := &data.AccountsData{AccountsWithStake: map[string]*data.AccountInfoWithStakeValues{"erd1a": {}}, Epoch: 7}
+	err := r.indexStakeHistory("accounts-000001_7", restAccounts)
+	require.Equal(t, "local error", err.Error())
+}
+
+func TestReindexer_PrepareStakeHistoryIndex(t *testing.T) {
+	t.Parallel()
+
+	t.Run("disabled should not touch the cluster", func(t *testing.T) {
+		t.Parallel()
+
+		r := &reindexer{pathToIndicesConfig: pathToIndicesConfig}
+		err := r.prepareStakeHistoryIndex(&mocks.ElasticClientStub{
+			PutIndexTemplateCalled: func(_ string, _ *bytes.Buffer) error {
+				require.Fail(t, "should have not been called")
+				return nil
+			},
+		})
+		require.Nil(t, err)
+	})
+
+	t.Run("should put the template and create the first index", func(t *testing.T) {
+		t.Parallel()
+
+		var template []byte
+		createdIndex, createdBody := "", ""
+		esClient := &mocks.ElasticClientStub{
+			GetPolicyCalled: func(policyName string) ([]byte, error) {
+				require.Equal(t, crossIndex.StakeHistoryPolicyName, policyName)
+				return nil, nil
+			},
+			PutPolicyCalled: func(policyName string, _ *bytes.Buffer) error {
+				require.Equal(t, crossIndex.StakeHistoryPolicyName, policyName)
+				return nil
+			},
+			PutIndexTemplateCalled: func(templateName string, buff *bytes.Buffer) error {
+				require.Equal(t, crossIndex.StakeHistoryAlias, templateName)
+				template = buff.Bytes()
+				return nil
+			},
+			CheckIfIndexExistsCalled: func(index string) (bool, error) {
+				require.Equal(t, crossIndex.StakeHistoryAlias, index)
+				return false, nil
+			},
+			CreateIndexWithMappingCalled: func(index string, buff *bytes.Buffer) error {
+				createdIndex, createdBody = index, buff.String()
+				return nil
+			},
+		}
+
+		r := &reindexer{pathToIndicesConfig: pathToIndicesConfig, stakeHistoryEnabled: true}
+		err := r.prepareStakeHistoryIndex(esClient)
+		require.Nil(t, err)
+
+		templateObj := struct {
+			Settings map[string]interface{} `json:"settings"`
+		}{}
+		err = json.Unmarshal(template, &templateObj)
+		require.Nil(t, err)
+		require.Equal(t, crossIndex.StakeHistoryPolicyName, templateObj.Settings[lifecycleNameSetting])
+		require.Equal(t, crossIndex.StakeHistoryAlias, templateObj.Settings[lifecycleRolloverAliasSetting])
+
+		require.Equal(t, "accounts-stake-history-000001", createdIndex)
+		require.Equal(t, `{"aliases":{"accounts-stake-history":{"is_write_index":true}}}`, createdBody)
+	})
+}
@@ -2,15 +2,26 @@ package reindexer
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"path"
+	"reflect"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
 )
 
 const (
 	accountsTemplateFileName = "accounts.json"
 	accountsPolicyFileName   = "accounts-policy.json"
 	valuesIndex              = "values"
+	runsIndex                = "accounts-manager-runs"
+
+	stakeHistoryTemplateFileName = "accounts-stake-history.json"
+	stakeHistoryPolicyFileName   = "accounts-stake-history-policy.json"
+
+	lifecycleNameSetting          = "index.lifecycle.name"
+	lifecycleRolloverAliasSetting = "index.lifecycle.rollover_alias"
 )
 
 func readTemplateAndPolicyForAccountsIndex(pathToIndicesConfig string) (*bytes.Buffer, *bytes.Buffer, error) {
@@ -34,6 +45,11 @@ func readTemplateForIndex(pathToIndicesConfig string, index string) (*bytes.Buff
 	return readFile(templatePath)
 }
 
+// runReportID returns the id of the document of a run report, made of the epoch and the start time of the run
+func runReportID(report *data.RunReport) string {
+	return fmt.Sprintf("%d_%d", report.Epoch, report.StartTime.UnixNano())
+}
+
 func readFile(path string) (*bytes.Buffer, error) {
 	fileBytes, err := ioutil.ReadFile(path)
 	if err != nil {
@@ -48,3 +64,65 @@ func readFile(path string) (*bytes.Buffer, error) {
 
 	return buff, nil
 }
+
+// addPolicyToTemplate will attach the provided lifecycle policy to the settings of the given index template
+func addPolicyToTemplate(template []byte, policyName string) ([]byte, error) {
+	templateObj := make(map[string]interface{})
+	err := json.Unmarshal(template, &templateObj)
+	if err != nil {
+		return nil, fmt.Errorf("cannot unmarshal index template: %w", err)
+	}
+
+	settings, ok := templateObj["settings"].(map[string]interface{})
+	if !ok {
+		settings = make(map[string]interface{})
+	}
+
+	settings[lifecycleNameSetting] = policyName
+	templateObj["settings"] = settings
+
+	return json.Marshal(templateObj)
+}
+
+// addRolloverAliasToTemplate will set the alias that is rolled over by the lifecycle policy of the given index template
+func addRolloverAliasToTemplate(template []byte, alias string) ([]byte, error) {
+	templateObj := make(map[string]interface{})
+	err := json.Unmarshal(template, &templateObj)
+	if err != nil {
+		return nil, fmt.Errorf("cannot unmarshal index template: %w", err)
+	}
+
+	settings, ok := templateObj["settings"].(map[string]interface{})
+	if !ok {
+		settings = make(map[string]interface{})
+	}
+
+	settings[lifecycleRolloverAliasSetting] = alias
+	templateObj["settings"] = settings
+
+	return json.Marshal(templateObj)
+}
+
+// isSamePolicy returns true if the policy stored in the cluster has the same phases as the policy from the file.
+// The cluster returns only the content of the "policy" field, while the file wraps it in a "policy" object
+func isSamePolicy(clusterPolicy []byte, filePolicy []byte) (bool, error) {
+	clusterPolicyObj := struct {
+		Phases interface{} `json:"phases"`
+	}{}
+	err := json.Unmarshal(clusterPolicy, &clusterPolicyObj)
+	if err != nil {
+		return false, fmt.Errorf("cannot unmarshal cluster policy: %w", err)
+	}
+
+	filePolicyObj := struct {
+		Policy struct {
+			Phases interface{} `json:"phases"`
+		} `json:"policy"`
+	}{}
+	err = json.Unmarshal(filePolicy, &filePolicyObj)
+	if err != nil {
+		return false, fmt.Errorf("cannot unmarshal file policy: %w", err)
+	}
+
+	return reflect.DeepEqual(clusterPolicyObj.Phases, filePolicyObj.Policy.Phases), nil
+}
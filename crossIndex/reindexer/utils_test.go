@@ -0,0 +1,38 @@
+package reindexer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddPolicyToTemplate(t *testing.T) {
+	t.Parallel()
+
+	template := []byte(`{"mappings":{"properties":{"balanceNum":{"type":"double"}}},"settings":{"number_of_shards":1}}`)
+
+	res, err := addPolicyToTemplate(template, "policy")
+	require.Nil(t, err)
+	require.JSONEq(t, `{"mappings":{"properties":{"balanceNum":{"type":"double"}}},"settings":{"number_of_shards":1,"index.lifecycle.name":"policy"}}`, string(res))
+
+	res, err = addPolicyToTemplate([]byte(`{}`), "policy")
+	require.Nil(t, err)
+	require.JSONEq(t, `{"settings":{"index.lifecycle.name":"policy"}}`, string(res))
+
+	_, err = addPolicyToTemplate([]byte(`{`), "policy")
+	require.NotNil(t, err)
+}
+
+func TestIsSamePolicy(t *testing.T) {
+	t.Parallel()
+
+	filePolicy := []byte(`{"policy":{"phases":{"delete":{"actions":{"delete":{}},"min_age":"90d"}}}}`)
+
+	isSame, err := isSamePolicy([]byte(`{"phases":{"delete":{"min_age":"90d","actions":{"delete":{}}}}}`), filePolicy)
+	require.Nil(t, err)
+	require.True(t, isSame)
+
+	isSame, err = isSamePolicy([]byte(`{"phases":{"delete":{"min_age":"30d","actions":{"delete":{}}}}}`), filePolicy)
+	require.Nil(t, err)
+	require.False(t, isSame)
+}
@@ -0,0 +1,77 @@
+package reindexer
+
+import (
+	"fmt"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+)
+
+const (
+	// WritePolicyAll requires every destination cluster to receive all the accounts
+	WritePolicyAll = "all"
+	// WritePolicyQuorum requires a quorum of the destination clusters to receive all the accounts
+	WritePolicyQuorum = "quorum"
+	// WritePolicyBestEffort requires at least one destination cluster to receive all the accounts
+	WritePolicyBestEffort = "best-effort"
+)
+
+// writePolicy decides if a run succeeded, depending on how many destination clusters received all the accounts
+type writePolicy struct {
+	mode            string
+	numDestinations int
+	numRequired     int
+}
+
+// newWritePolicy will create a new writePolicy. An empty mode requires all the destinations and a quorum of 0
+// requires the majority of the destinations
+func newWritePolicy(cfg config.WritePolicyConfig, numDestinations int) (*writePolicy, error) {
+	wp := &writePolicy{
+		mode:            cfg.Mode,
+		numDestinations: numDestinations,
+	}
+
+	switch cfg.Mode {
+	case "", WritePolicyAll:
+		wp.mode = WritePolicyAll
+		wp.numRequired = numDestinations
+	case WritePolicyQuorum:
+		if cfg.Quorum < 0 || cfg.Quorum > numDestinations {
+			return nil, fmt.Errorf("%w: %d out of %d destinations", ErrInvalidWriteQuorum, cfg.Quorum, numDestinations)
+		}
+
+		wp.numRequired = cfg.Quorum
+		if wp.numRequired == 0 {
+			wp.numRequired = numDestinations/2 + 1
+		}
+	case WritePolicyBestEffort:
+		wp.numRequired = 1
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidWritePolicy, cfg.Mode)
+	}
+
+	if wp.numRequired > numDestinations {
+		wp.numRequired = numDestinations
+	}
+
+	return wp, nil
+}
+
+// canSucceed returns true if the run can still succeed after the provided number of destinations failed
+func (wp *writePolicy) canSucceed(numFailed int) bool {
+	return wp.numDestinations-numFailed >= wp.numRequired
+}
+
+// checkFailures returns an error if too many destinations failed
+func (wp *writePolicy) checkFailures(numFailed int) error {
+	if wp.canSucceed(numFailed) {
+		return nil
+	}
+
+	return fmt.Errorf("%w %s: %d out of %d destinations failed, %d are required",
+		ErrWritePolicyNotSatisfied, wp.mode, numFailed, wp.numDestinations, wp.numRequired)
+}
+
+// allowsFailures returns true if a destination can fail without failing the run
+func (wp *writePolicy) allowsFailures() bool {
+	return wp.numRequired < wp.numDestinations
+}
@@ -0,0 +1,55 @@
+package reindexer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWritePolicy(t *testing.T) {
+	t.Parallel()
+
+	wp, err := newWritePolicy(config.WritePolicyConfig{}, 3)
+	require.Nil(t, err)
+	require.Equal(t, WritePolicyAll, wp.mode)
+	require.Equal(t, 3, wp.numRequired)
+	require.False(t, wp.allowsFailures())
+
+	wp, err = newWritePolicy(config.WritePolicyConfig{Mode: WritePolicyQuorum}, 3)
+	require.Nil(t, err)
+	require.Equal(t, 2, wp.numRequired)
+
+	wp, err = newWritePolicy(config.WritePolicyConfig{Mode: WritePolicyQuorum, Quorum: 1}, 3)
+	require.Nil(t, err)
+	require.Equal(t, 1, wp.numRequired)
+
+	wp, err = newWritePolicy(config.WritePolicyConfig{Mode: WritePolicyBestEffort}, 3)
+	require.Nil(t, err)
+	require.Equal(t, 1, wp.numRequired)
+
+	wp, err = newWritePolicy(config.WritePolicyConfig{Mode: WritePolicyBestEffort}, 0)
+	require.Nil(t, err)
+	require.Equal(t, 0, wp.numRequired)
+
+	_, err = newWritePolicy(config.WritePolicyConfig{Mode: WritePolicyQuorum, Quorum: 4}, 3)
+	require.True(t, errors.Is(err, ErrInvalidWriteQuorum))
+
+	_, err = newWritePolicy(config.WritePolicyConfig{Mode: "some"}, 3)
+	require.True(t, errors.Is(err, ErrInvalidWritePolicy))
+}
+
+func TestWritePolicy_CheckFailures(t *testing.T) {
+	t.Parallel()
+
+	wp, _ := newWritePolicy(config.WritePolicyConfig{Mode: WritePolicyQuorum}, 3)
+	require.True(t, wp.allowsFailures())
+	require.Nil(t, wp.checkFailures(0))
+	require.Nil(t, wp.checkFailures(1))
+	require.True(t, errors.Is(wp.checkFailures(2), ErrWritePolicyNotSatisfied))
+
+	wp, _ = newWritePolicy(config.WritePolicyConfig{Mode: WritePolicyBestEffort}, 3)
+	require.True(t, wp.canSucceed(2))
+	require.False(t, wp.canSucceed(3))
+}
@@ -0,0 +1,35 @@
+package crossIndex
+
+import (
+	"fmt"
+
+	"github.com/tidwall/gjson"
+)
+
+// SnapshotStats holds the stake totals of an accounts index and the number of accounts of every stake source
+type SnapshotStats struct {
+	NumAccounts    float64            `json:"numAccounts"`
+	Sums           map[string]float64 `json:"totals"`
+	SourceAccounts map[string]float64 `json:"sourceAccounts"`
+}
+
+// ParseSnapshotStats will extract the snapshot stats from the response of a GetSnapshotStats query
+func ParseSnapshotStats(responseBytes []byte) (*SnapshotStats, error) {
+	if !gjson.ValidBytes(responseBytes) {
+		return nil, fmt.Errorf("invalid snapshot stats response: %s", string(responseBytes))
+	}
+
+	stats := &SnapshotStats{
+		NumAccounts:    gjson.GetBytes(responseBytes, "hits.total.value").Float(),
+		Sums:           make(map[string]float64),
+		SourceAccounts: make(map[string]float64),
+	}
+	for name := range SnapshotSumAggregations {
+		stats.Sums[name] = gjson.GetBytes(responseBytes, "aggregations."+name+".value").Float()
+	}
+	for source := range SnapshotSourceFields {
+		stats.SourceAccounts[source] = gjson.GetBytes(responseBytes, "aggregations."+source+".doc_count").Float()
+	}
+
+	return stats, nil
+}
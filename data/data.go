@@ -2,6 +2,9 @@ package data
 
 import (
 	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/multiversx/mx-chain-core-go/data/vm"
 	"github.com/multiversx/mx-chain-es-indexer-go/data"
@@ -21,6 +24,21 @@ type BlockInfo struct {
 	RootHash string `json:"rootHash"`
 }
 
+// EpochStatus holds the current epoch of the network and the time at which it started
+type EpochStatus struct {
+	Epoch     uint32
+	StartTime time.Time
+}
+
+// SnapshotBlock holds the blocks at which all the stake sources are read. The shard blocks are the ones notarized
+// by the metachain block
+type SnapshotBlock struct {
+	Epoch       uint32                `json:"epoch"`
+	NumShards   uint32                `json:"numShards"`
+	MetaBlock   *BlockInfo            `json:"metaBlock"`
+	ShardBlocks map[uint32]*BlockInfo `json:"shardBlocks"`
+}
+
 // StakedInfo defines the structure of a response staked info response
 type StakedInfo struct {
 	Address string `json:"address"`
@@ -38,9 +56,19 @@ type DelegatorStake struct {
 	Total string `json:"total"`
 }
 
+// DelegationInfo holds the stake of an account with a staking provider
+type DelegationInfo struct {
+	DelegationScAddress string  `json:"delegationScAddress"`
+	Value               string  `json:"value,omitempty"`
+	ValueNum            float64 `json:"valueNum,omitempty"`
+	UnDelegateValue     string  `json:"unDelegateValue,omitempty"`
+	UnDelegateValueNum  float64 `json:"unDelegateValueNum,omitempty"`
+}
+
 // VmValuesResponseData follows the format of the data field in an API response for a VM values query
 type VmValuesResponseData struct {
-	Data *vm.VMOutputApi `json:"data"`
+	Data      *vm.VMOutputApi `json:"data"`
+	BlockInfo *BlockInfo      `json:"blockInfo,omitempty"`
 }
 
 // ResponseVmValue defines a wrapper over string containing returned data in hex format
@@ -77,16 +105,31 @@ type AccountsResponseES struct {
 
 // BulkRequestResponse defines the structure of a bulk request response
 type BulkRequestResponse struct {
-	Errors bool `json:"errors"`
-	Items  []struct {
-		Index struct {
-			Status int `json:"status"`
-			Error  struct {
-				Type   string `json:"type"`
-				Reason string `json:"reason"`
-			} `json:"error"`
-		} `json:"index"`
-	} `json:"items"`
+	Errors bool               `json:"errors"`
+	Items  []BulkResponseItem `json:"items"`
+}
+
+// BulkRequestError is returned when the elastic cluster refused a whole bulk request, holding the status of the response
+type BulkRequestError struct {
+	StatusCode int
+	Message    string
+}
+
+// Error returns the message of the error
+func (e *BulkRequestError) Error() string {
+	return e.Message
+}
+
+// BulkResponseItem defines the structure of the result of an item from a bulk request response
+type BulkResponseItem struct {
+	Index struct {
+		ID     string `json:"_id"`
+		Status int    `json:"status"`
+		Error  struct {
+			Type   string `json:"type"`
+			Reason string `json:"reason"`
+		} `json:"error"`
+	} `json:"index"`
 }
 
 // AccountInfoWithStakeValues extends the structure data.AccountInfo with stake values
@@ -99,23 +142,96 @@ type AccountsData struct {
 	AccountsWithStake map[string]*AccountInfoWithStakeValues
 	Addresses         []string
 	EnergyBlockInfo   *BlockInfo
+	SnapshotBlock     *SnapshotBlock
 	Epoch             uint32
+	Sources           []SourceInfo
+}
+
+// SourceInfo holds information about the accounts fetched from a stake source
+type SourceInfo struct {
+	Name              string     `json:"name"`
+	NumAccounts       int        `json:"numAccounts"`
+	DurationInSeconds float64    `json:"durationInSeconds"`
+	BlockInfo         *BlockInfo `json:"blockInfo,omitempty"`
+	Error             string     `json:"error,omitempty"`
+}
+
+const (
+	// RunStatusSuccess is the status of a run that indexed all the accounts
+	RunStatusSuccess = "success"
+	// RunStatusFailure is the status of a run that ended with an error
+	RunStatusFailure = "failure"
+)
+
+// RunReport holds the provenance and the outcome of a run
+type RunReport struct {
+	Epoch                uint32               `json:"epoch"`
+	Version              string               `json:"version"`
+	StartTime            time.Time            `json:"startTime"`
+	EndTime              time.Time            `json:"endTime"`
+	DurationInSeconds    float64              `json:"durationInSeconds"`
+	Status               string               `json:"status"`
+	Error                string               `json:"error,omitempty"`
+	Index                string               `json:"index,omitempty"`
+	SnapshotBlock        *SnapshotBlock       `json:"snapshotBlock,omitempty"`
+	Sources              []SourceInfo         `json:"sources"`
+	NumAccountsWithStake int                  `json:"numAccountsWithStake"`
+	NumMergedAccounts    int                  `json:"numMergedAccounts"`
+	Destinations         []*DestinationReport `json:"destinations"`
+	Warnings             []string             `json:"warnings"`
+	NumOmittedWarnings   int                  `json:"numOmittedWarnings,omitempty"`
+}
+
+const maxRunReportWarnings = 100
+
+// AddWarning will save a warning of the run, followed by its key-value pairs. Only the first warnings are saved and the
+// others are counted. It is not safe for concurrent use, the warnings are added from the goroutine of the run
+func (rr *RunReport) AddWarning(message string, args ...interface{}) {
+	if len(rr.Warnings) >= maxRunReportWarnings {
+		rr.NumOmittedWarnings++
+		return
+	}
+
+	parts := make([]string, 0, len(args)/2+1)
+	parts = append(parts, message)
+	for idx := 1; idx < len(args); idx += 2 {
+		parts = append(parts, fmt.Sprintf("%v = %v", args[idx-1], args[idx]))
+	}
+
+	rr.Warnings = append(rr.Warnings, strings.Join(parts, " "))
+}
+
+const (
+	// DestinationStatusOK is the status of a destination that received all the accounts
+	DestinationStatusOK = "ok"
+	// DestinationStatusFailed is the status of a destination that missed some accounts and has to be re-filled
+	DestinationStatusFailed = "failed"
+)
+
+// DestinationReport holds the number of accounts written in a destination during a run
+type DestinationReport struct {
+	Name               string `json:"name"`
+	Status             string `json:"status"`
+	Error              string `json:"error,omitempty"`
+	NumBulks           int    `json:"numBulks"`
+	NumIndexedAccounts int    `json:"numIndexedAccounts"`
 }
 
 // StakeInfo is the structure that contains all information about stake for an account
 type StakeInfo struct {
-	DelegationLegacyWaiting    string  `json:"delegationLegacyWaiting,omitempty"`
-	DelegationLegacyWaitingNum float64 `json:"delegationLegacyWaitingNum,omitempty"`
-	DelegationLegacyActive     string  `json:"delegationLegacyActive,omitempty"`
-	DelegationLegacyActiveNum  float64 `json:"delegationLegacyActiveNum,omitempty"`
-	ValidatorsActive           string  `json:"validatorsActive,omitempty"`
-	ValidatorsActiveNum        float64 `json:"validatorsActiveNum,omitempty"`
-	ValidatorTopUp             string  `json:"validatorsTopUp,omitempty"`
-	ValidatorTopUpNum          float64 `json:"validatorsTopUpNum,omitempty"`
-	Delegation                 string  `json:"delegation,omitempty"`
-	DelegationNum              float64 `json:"delegationNum,omitempty"`
-	TotalStake                 string  `json:"totalStake,omitempty"`
-	TotalStakeNum              float64 `json:"totalStakeNum,omitempty"`
+	DelegationLegacyWaiting    string            `json:"delegationLegacyWaiting,omitempty"`
+	DelegationLegacyWaitingNum float64           `json:"delegationLegacyWaitingNum,omitempty"`
+	DelegationLegacyActive     string            `json:"delegationLegacyActive,omitempty"`
+	DelegationLegacyActiveNum  float64           `json:"delegationLegacyActiveNum,omitempty"`
+	ValidatorsActive           string            `json:"validatorsActive,omitempty"`
+	ValidatorsActiveNum        float64           `json:"validatorsActiveNum,omitempty"`
+	ValidatorTopUp             string            `json:"validatorsTopUp,omitempty"`
+	ValidatorTopUpNum          float64           `json:"validatorsTopUpNum,omitempty"`
+	Delegation                 string            `json:"delegation,omitempty"`
+	DelegationNum              float64           `json:"delegationNum,omitempty"`
+	DelegatedTo                []*DelegationInfo `json:"delegatedTo,omitempty"`
+	TotalStake                 string            `json:"totalStake,omitempty"`
+	TotalStakeNum              float64           `json:"totalStakeNum,omitempty"`
 
 	LKMEXStake    string         `json:"lkMexStake,omitempty"`
 	LKMEXStakeNum float64        `json:"lkMexStakeNum,omitempty"`
@@ -133,6 +249,29 @@ type StakeInfo struct {
 	TotalUnDelegateNum      float64 `json:"totalUnDelegateNum,omitempty"`
 }
 
+// NetworkTotals holds the network-wide totals of an epoch, computed from all the accounts with stake
+type NetworkTotals struct {
+	Epoch                uint32                 `json:"epoch"`
+	Totals               map[string]*TotalValue `json:"totals"`
+	NumAccountsWithStake int                    `json:"numAccountsWithStake"`
+	SourceAccounts       map[string]int         `json:"sourceAccounts"`
+}
+
+// TotalValue holds a total as a big integer and as a denominated float
+type TotalValue struct {
+	Value    string  `json:"value"`
+	ValueNum float64 `json:"valueNum"`
+}
+
+// AccountStakeHistory is the compact document saved in the stake history index for an account, once per epoch
+type AccountStakeHistory struct {
+	Address    string  `json:"address"`
+	Epoch      uint32  `json:"epoch"`
+	Balance    string  `json:"balance"`
+	BalanceNum float64 `json:"balanceNum"`
+	StakeInfo
+}
+
 // EnergyDetails is the structure that contains details about the user's energy
 type EnergyDetails struct {
 	LastUpdateEpoch   uint32 `json:"lastUpdateEpoch"`
@@ -146,11 +285,30 @@ type KeyValueObj struct {
 	Value string `json:"value"`
 }
 
+// ExportManifest holds the details of an accounts export
+type ExportManifest struct {
+	Epoch     uint32     `json:"epoch"`
+	BlockInfo *BlockInfo `json:"blockInfo"`
+	NumRows   uint64     `json:"numRows"`
+	Format    string     `json:"format"`
+	FileName  string     `json:"fileName,omitempty"`
+	Timestamp int64      `json:"timestamp"`
+}
+
 // EsClientConfig is a wrapper over the internally used field from elasticsearch.Config struct
 type EsClientConfig struct {
-	Address  string
-	Username string
-	Password string
+	Address      string
+	Username     string
+	Password     string
+	PasswordFile string
+}
+
+// PointInTimeArgs holds the arguments used to read all the documents of an index with a point in time
+type PointInTimeArgs struct {
+	AfterID    string
+	PageSize   int
+	KeepAlive  time.Duration
+	MaxRetries int
 }
 
 // RestApiAuthenticationData holds the data to be used when authorizing API requests
@@ -14,6 +14,7 @@ import (
 	"github.com/elastic/go-elasticsearch/v7/esapi"
 	logger "github.com/multiversx/mx-chain-logger-go"
 	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/metrics"
 	"github.com/tidwall/gjson"
 )
 
@@ -26,9 +27,10 @@ const (
 var log = logger.GetOrCreate("elasticClient")
 
 type esClient struct {
-	client      *elasticsearch.Client
-	countScroll int
-	clusterURL  string
+	client                *elasticsearch.Client
+	countScroll           int
+	clusterURL            string
+	pointInTimeRetryDelay time.Duration
 }
 
 // NewElasticClient will create a new instance of an esClient
@@ -39,45 +41,82 @@ func NewElasticClient(cfg data.EsClientConfig) (*esClient, error) {
 	}
 
 	return &esClient{
-		clusterURL:  cfg.Address,
-		client:      elasticClient,
-		countScroll: 0,
+		clusterURL:            cfg.Address,
+		client:                elasticClient,
+		countScroll:           0,
+		pointInTimeRetryDelay: defaultPointInTimeRetryDelay,
 	}, nil
 }
 
+// CheckConnection will check that the cluster is reachable and accepts the configured credentials
+func (ec *esClient) CheckConnection() error {
+	res, err := ec.client.Info()
+	if err != nil {
+		return err
+	}
+
+	defer closeBody(res)
+
+	if res.IsError() {
+		return fmt.Errorf("error CheckConnection: %s, url: %s", res.Status(), ec.clusterURL)
+	}
+
+	return nil
+}
+
 // DoBulkRequest will do a bulk of request to elastic server
 func (ec *esClient) DoBulkRequest(buff *bytes.Buffer, index string) error {
+	bulkResponse, err := ec.DoBulkRequestWithItems(buff, index)
+	if err != nil {
+		return err
+	}
+	if bulkResponse.Errors {
+		return extractErrorFromBulkResponse(bulkResponse)
+	}
+
+	return nil
+}
+
+// DoBulkRequestWithItems will do a bulk request to the elastic server and will return the result of every item. An
+// error is returned only if the whole request failed
+func (ec *esClient) DoBulkRequestWithItems(buff *bytes.Buffer, index string) (*data.BulkRequestResponse, error) {
 	reader := bytes.NewReader(buff.Bytes())
+	numItemErrors := 0
+	defer func(numBytes int) {
+		metrics.ObserveBulkRequest(ec.clusterURL, numBytes, numItemErrors)
+	}(buff.Len())
 
 	res, err := ec.client.Bulk(
 		reader,
 		ec.client.Bulk.WithIndex(index),
 	)
 	if err != nil {
-		return err
-	}
-	if res.IsError() {
-		return fmt.Errorf("error DoBulkRequest: %s, url: %s", res.String(), ec.clusterURL)
+		return nil, err
 	}
 
 	defer closeBody(res)
 
+	if res.IsError() {
+		return nil, &data.BulkRequestError{
+			StatusCode: res.StatusCode,
+			Message:    fmt.Sprintf("error DoBulkRequest: %s, url: %s", res.String(), ec.clusterURL),
+		}
+	}
+
 	bodyBytes, errRead := ioutil.ReadAll(res.Body)
 	if errRead != nil {
-		return errRead
+		return nil, errRead
 	}
 
 	bulkResponse := &data.BulkRequestResponse{}
 	err = json.Unmarshal(bodyBytes, bulkResponse)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if bulkResponse.Errors {
-		return extractErrorFromBulkResponse(bulkResponse)
-	}
+	numItemErrors = countBulkItemErrors(bulkResponse)
 
-	return nil
+	return bulkResponse, nil
 }
 
 // DoRequest will do a index request to Elasticsearch
@@ -93,15 +132,12 @@ func (ec *esClient) DoRequest(index, documentID string, buff *bytes.Buffer) erro
 		return err
 	}
 
-	if err != nil {
-		return err
-	}
+	defer closeBody(res)
+
 	if res.IsError() {
 		return fmt.Errorf("error DoRequest: %s", res.String())
 	}
 
-	defer closeBody(res)
-
 	return nil
 }
 
@@ -148,12 +184,13 @@ func (ec *esClient) DoMultiGet(ids []string, index string) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+
+	defer closeBody(res)
+
 	if res.IsError() {
 		return nil, fmt.Errorf("error DoMultiGet: %s", res.String())
 	}
 
-	defer closeBody(res)
-
 	bodyBytes, errRead := ioutil.ReadAll(res.Body)
 	if errRead != nil {
 		return nil, errRead
@@ -173,12 +210,12 @@ func (ec *esClient) PutMapping(targetIndex string, body *bytes.Buffer) error {
 		return err
 	}
 
+	defer closeBody(res)
+
 	if res.IsError() {
 		return fmt.Errorf("error PutMapping: %s", res.String())
 	}
 
-	defer closeBody(res)
-
 	return nil
 }
 
@@ -193,12 +230,31 @@ func (ec *esClient) CreateIndexWithMapping(index string, mapping *bytes.Buffer)
 		return err
 	}
 
+	defer closeBody(res)
+
 	if res.IsError() {
 		return fmt.Errorf("error CreateIndexWithMapping: %s, url: %s", res.String(), ec.clusterURL)
 	}
 
+	return nil
+}
+
+// PutIndexTemplate will put in Elasticsearch cluster the provided index template with the given name
+func (ec *esClient) PutIndexTemplate(templateName string, template *bytes.Buffer) error {
+	res, err := ec.client.Indices.PutTemplate(
+		templateName,
+		template,
+	)
+	if err != nil {
+		return err
+	}
+
 	defer closeBody(res)
 
+	if res.IsError() {
+		return fmt.Errorf("error PutIndexTemplate: %s, url: %s", res.String(), ec.clusterURL)
+	}
+
 	return nil
 }
 
@@ -231,6 +287,212 @@ func (ec *esClient) PutPolicy(policyName string, policy *bytes.Buffer) error {
 	return nil
 }
 
+// GetPolicy will return the body of the policy with the given name. A nil slice is returned if the policy does not exist
+func (ec *esClient) GetPolicy(policyName string) ([]byte, error) {
+	res, err := ec.client.ILM.GetLifecycle(
+		ec.client.ILM.GetLifecycle.WithPolicy(policyName),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer closeBody(res)
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if res.IsError() {
+		return nil, fmt.Errorf("error GetPolicy: %s, url: %s", res.String(), ec.clusterURL)
+	}
+
+	bodyBytes, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	policiesResponse := make(map[string]struct {
+		Policy json.RawMessage `json:"policy"`
+	})
+	err = json.Unmarshal(bodyBytes, &policiesResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return policiesResponse[policyName].Policy, nil
+}
+
+// GetAliasIndices will return the indices the provided alias points to
+func (ec *esClient) GetAliasIndices(alias string) ([]string, error) {
+	res, err := ec.client.Indices.GetAlias(
+		ec.client.Indices.GetAlias.WithName(alias),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer closeBody(res)
+
+	if res.StatusCode == http.StatusNotFound {
+		return []string{}, nil
+	}
+	if res.IsError() {
+		return nil, fmt.Errorf("error GetAliasIndices: %s, url: %s", res.String(), ec.clusterURL)
+	}
+
+	bodyBytes, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	aliasesResponse := make(map[string]interface{})
+	err = json.Unmarshal(bodyBytes, &aliasesResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	indices := make([]string, 0, len(aliasesResponse))
+	for index := range aliasesResponse {
+		indices = append(indices, index)
+	}
+
+	return indices, nil
+}
+
+// UpdateAliases will atomically move the provided alias from the old indices to the new index
+func (ec *esClient) UpdateAliases(alias string, oldIndices []string, newIndex string) error {
+	buff, err := getMoveAliasQueryEncoded(alias, oldIndices, newIndex)
+	if err != nil {
+		return err
+	}
+
+	res, err := ec.client.Indices.UpdateAliases(buff)
+	if err != nil {
+		return err
+	}
+
+	defer closeBody(res)
+
+	if res.IsError() {
+		return fmt.Errorf("error UpdateAliases: %s, url: %s", res.String(), ec.clusterURL)
+	}
+
+	return nil
+}
+
+// DoSearchRequest will perform a search request on the provided index and will return the body of the response
+func (ec *esClient) DoSearchRequest(index string, body []byte) ([]byte, error) {
+	res, err := ec.client.Search(
+		ec.client.Search.WithIndex(index),
+		ec.client.Search.WithBody(bytes.NewBuffer(body)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if res.IsError() {
+		closeBody(res)
+		return nil, fmt.Errorf("error DoSearchRequest: %s, url: %s", res.String(), ec.clusterURL)
+	}
+
+	return getBytesFromResponse(res)
+}
+
+// RefreshIndex will make all the documents indexed in the provided index visible to the search requests
+func (ec *esClient) RefreshIndex(index string) error {
+	res, err := ec.client.Indices.Refresh(
+		ec.client.Indices.Refresh.WithIndex(index),
+	)
+	if err != nil {
+		return err
+	}
+
+	defer closeBody(res)
+
+	if res.IsError() {
+		return fmt.Errorf("error RefreshIndex: %s, url: %s", res.String(), ec.clusterURL)
+	}
+
+	return nil
+}
+
+// GetIndices will return the names of the indices that match the provided pattern
+func (ec *esClient) GetIndices(pattern string) ([]string, error) {
+	res, err := ec.client.Cat.Indices(
+		ec.client.Cat.Indices.WithIndex(pattern),
+		ec.client.Cat.Indices.WithFormat("json"),
+		ec.client.Cat.Indices.WithH("index"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer closeBody(res)
+
+	if res.StatusCode == http.StatusNotFound {
+		return []string{}, nil
+	}
+	if res.IsError() {
+		return nil, fmt.Errorf("error GetIndices: %s, url: %s", res.String(), ec.clusterURL)
+	}
+
+	bodyBytes, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	indicesResponse := make([]struct {
+		Index string `json:"index"`
+	}, 0)
+	err = json.Unmarshal(bodyBytes, &indicesResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	indices := make([]string, 0, len(indicesResponse))
+	for _, index := range indicesResponse {
+		indices = append(indices, index.Index)
+	}
+
+	return indices, nil
+}
+
+// DeleteIndex will delete the provided index
+func (ec *esClient) DeleteIndex(index string) error {
+	res, err := ec.client.Indices.Delete([]string{index})
+	if err != nil {
+		return err
+	}
+
+	defer closeBody(res)
+
+	if res.IsError() {
+		return fmt.Errorf("error DeleteIndex: %s, url: %s", res.String(), ec.clusterURL)
+	}
+
+	return nil
+}
+
+// DeleteByQuery will delete the documents of the provided index, or of all the indices behind the provided alias, that
+// match the query
+func (ec *esClient) DeleteByQuery(index string, body []byte) error {
+	res, err := ec.client.DeleteByQuery(
+		[]string{index},
+		bytes.NewBuffer(body),
+		ec.client.DeleteByQuery.WithConflicts("proceed"),
+		ec.client.DeleteByQuery.WithRefresh(true),
+	)
+	if err != nil {
+		return err
+	}
+
+	defer closeBody(res)
+
+	if res.IsError() {
+		return fmt.Errorf("error DeleteByQuery: %s, url: %s", res.String(), ec.clusterURL)
+	}
+
+	return nil
+}
+
 // DoScrollRequestAllDocuments will perform a documents request using scroll api
 func (ec *esClient) DoScrollRequestAllDocuments(
 	index string,
@@ -249,6 +511,7 @@ func (ec *esClient) DoScrollRequestAllDocuments(
 		return err
 	}
 	if res.IsError() {
+		closeBody(res)
 		return fmt.Errorf("error DoScrollRequestAllDocuments: %s", res.String())
 	}
 
@@ -256,6 +519,7 @@ func (ec *esClient) DoScrollRequestAllDocuments(
 	if errGet != nil {
 		return errGet
 	}
+	metrics.IncScrollPages(ec.clusterURL)
 
 	err = handlerFunc(bodyBytes)
 	if err != nil {
@@ -290,6 +554,8 @@ func (ec *esClient) iterateScroll(
 		if numberOfHits.Int() < 1 {
 			return nil
 		}
+		metrics.IncScrollPages(ec.clusterURL)
+
 		err := handlerFunc(scrollBodyBytes)
 		if err != nil {
 			return err
@@ -318,12 +584,13 @@ func (ec *esClient) clearScroll(scrollID string) error {
 	if err != nil {
 		return err
 	}
+
+	defer closeBody(resp)
+
 	if resp.IsError() && resp.StatusCode != http.StatusNotFound {
 		return fmt.Errorf("error response: %s", resp.String())
 	}
 
-	defer closeBody(resp)
-
 	return nil
 }
 
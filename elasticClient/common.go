@@ -30,10 +30,11 @@ func closeBody(res *esapi.Response) {
 }
 
 func getBytesFromResponse(res *esapi.Response) ([]byte, error) {
+	defer closeBody(res)
+
 	if res.IsError() {
 		return nil, fmt.Errorf("error response: %s", res.String())
 	}
-	defer closeBody(res)
 
 	bodyBytes, err := ioutil.ReadAll(res.Body)
 	if err != nil {
@@ -43,6 +44,17 @@ func getBytesFromResponse(res *esapi.Response) ([]byte, error) {
 	return bodyBytes, nil
 }
 
+func countBulkItemErrors(response *data.BulkRequestResponse) int {
+	count := 0
+	for _, item := range response.Items {
+		if item.Index.Status >= 300 {
+			count++
+		}
+	}
+
+	return count
+}
+
 func extractErrorFromBulkResponse(response *data.BulkRequestResponse) error {
 	count := 0
 	errorsString := ""
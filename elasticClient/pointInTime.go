@@ -0,0 +1,183 @@
+package elasticClient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/metrics"
+	"github.com/tidwall/gjson"
+)
+
+const defaultPointInTimeRetryDelay = 2 * time.Second
+
+// DoPointInTimeRequestAllDocuments will fetch all the documents of the provided index that match the query from the
+// body, page by page, sorted by _id with search_after inside a point in time. The point in time gives a consistent view
+// of the index while it is still written. When a page cannot be fetched, for example because a node restarted and the
+// point in time was lost, a new point in time is opened and the reading continues after the last fetched document
+func (ec *esClient) DoPointInTimeRequestAllDocuments(
+	index string,
+	body []byte,
+	args data.PointInTimeArgs,
+	handlerFunc func(responseBytes []byte) error,
+) error {
+	query := make(map[string]interface{})
+	err := json.Unmarshal(body, &query)
+	if err != nil {
+		return err
+	}
+
+	keepAlive := fmt.Sprintf("%dms", args.KeepAlive.Milliseconds())
+	pitID := ""
+	defer func() {
+		ec.closePointInTime(pitID)
+	}()
+
+	afterID := args.AfterID
+	numFailures := 0
+	for {
+		if pitID == "" {
+			pitID, err = ec.openPointInTime(index, keepAlive)
+		}
+		var responseBytes []byte
+		if err == nil {
+			responseBytes, err = ec.searchAfter(query, pitID, keepAlive, afterID, args.PageSize)
+		}
+		if err != nil {
+			numFailures++
+			if numFailures > args.MaxRetries {
+				return err
+			}
+
+			if pitID != "" && (isPointInTimeMissing(err) || numFailures > 1) {
+				log.Warn("cannot fetch documents with point in time, a new point in time will be opened and "+
+					"the documents written since the first one was opened may be read",
+					"index", index, "after", afterID, "attempt", numFailures, "error", err)
+				ec.closePointInTime(pitID)
+				pitID = ""
+			} else {
+				log.Warn("cannot fetch documents with point in time, retrying",
+					"index", index, "after", afterID, "attempt", numFailures, "error", err)
+			}
+			err = nil
+			time.Sleep(time.Duration(numFailures) * ec.pointInTimeRetryDelay)
+			continue
+		}
+		numFailures = 0
+
+		newPitID := gjson.GetBytes(responseBytes, "pit_id").String()
+		if newPitID != "" {
+			pitID = newPitID
+		}
+
+		numHits := gjson.GetBytes(responseBytes, "hits.hits.#").Int()
+		if numHits == 0 {
+			return nil
+		}
+		metrics.IncScrollPages(ec.clusterURL)
+
+		err = handlerFunc(responseBytes)
+		if err != nil {
+			return err
+		}
+		if numHits < int64(args.PageSize) {
+			return nil
+		}
+
+		afterID = gjson.GetBytes(responseBytes, fmt.Sprintf("hits.hits.%d._id", numHits-1)).String()
+	}
+}
+
+// isPointInTimeMissing returns true if the point in time expired or was lost, for example because a node restarted
+func isPointInTimeMissing(err error) bool {
+	return strings.Contains(err.Error(), "search_context_missing_exception") ||
+		strings.Contains(err.Error(), "No search context found")
+}
+
+func (ec *esClient) openPointInTime(index string, keepAlive string) (string, error) {
+	res, err := ec.client.OpenPointInTime(
+		ec.client.OpenPointInTime.WithIndex(index),
+		ec.client.OpenPointInTime.WithKeepAlive(keepAlive),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	bodyBytes, err := getBytesFromResponse(res)
+	if err != nil {
+		return "", err
+	}
+
+	pitID := gjson.GetBytes(bodyBytes, "id").String()
+	if pitID == "" {
+		return "", fmt.Errorf("empty point in time id for index %s, url: %s", index, ec.clusterURL)
+	}
+
+	return pitID, nil
+}
+
+func (ec *esClient) searchAfter(query map[string]interface{}, pitID string, keepAlive string, afterID string, pageSize int) ([]byte, error) {
+	query["size"] = pageSize
+	query["track_total_hits"] = false
+	query["pit"] = objectsMap{
+		"id":         pitID,
+		"keep_alive": keepAlive,
+	}
+	query["sort"] = []interface{}{
+		objectsMap{
+			"_id": objectsMap{
+				"order": "asc",
+			},
+		},
+	}
+	delete(query, "search_after")
+	if afterID != "" {
+		query["search_after"] = []interface{}{afterID}
+	}
+
+	body, err := encode(query)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := ec.client.Search(
+		ec.client.Search.WithContext(context.Background()),
+		ec.client.Search.WithBody(body),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	bodyBytes, err := getBytesFromResponse(res)
+	if err != nil {
+		return nil, err
+	}
+
+	// a page with failed shards misses documents, so it is fetched again
+	numFailedShards := gjson.GetBytes(bodyBytes, "_shards.failed").Int()
+	if numFailedShards > 0 {
+		return nil, fmt.Errorf("%d shards failed: %s", numFailedShards, gjson.GetBytes(bodyBytes, "_shards.failures").String())
+	}
+
+	return bodyBytes, nil
+}
+
+func (ec *esClient) closePointInTime(pitID string) {
+	if pitID == "" {
+		return
+	}
+
+	body, _ := encode(objectsMap{"id": pitID})
+	res, err := ec.client.ClosePointInTime(
+		ec.client.ClosePointInTime.WithBody(body),
+	)
+	if err == nil {
+		_, err = getBytesFromResponse(res)
+	}
+	if err != nil {
+		log.Debug("cannot close point in time", "error", err)
+	}
+}
@@ -0,0 +1,117 @@
+package elasticClient
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+type pointInTimeServer struct {
+	mut            sync.Mutex
+	ids            []string
+	numOpened      int
+	closedPits     []string
+	searchAfter    []string
+	lostPitOnCall  int
+	numSearchCalls int
+}
+
+func (s *pointInTimeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	body, _ := ioutil.ReadAll(r.Body)
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/accounts-000001/_pit":
+		s.numOpened++
+		_, _ = fmt.Fprintf(w, `{"id":"pit-%d"}`, s.numOpened)
+	case r.Method == http.MethodDelete && r.URL.Path == "/_pit":
+		s.closedPits = append(s.closedPits, gjson.GetBytes(body, "id").String())
+		_, _ = w.Write([]byte(`{"succeeded":true}`))
+	case r.URL.Path == "/_search":
+		s.numSearchCalls++
+		if s.numSearchCalls == s.lostPitOnCall {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"type":"search_context_missing_exception"},"status":404}`))
+			return
+		}
+
+		afterID := gjson.GetBytes(body, "search_after.0").String()
+		s.searchAfter = append(s.searchAfter, afterID)
+		size := int(gjson.GetBytes(body, "size").Int())
+
+		hits := make([]string, 0)
+		for _, id := range s.ids {
+			if id > afterID && len(hits) < size {
+				hits = append(hits, fmt.Sprintf(`{"_id":"%s","_source":{"address":"%s"}}`, id, id))
+			}
+		}
+		_, _ = fmt.Fprintf(w, `{"pit_id":"%s","_shards":{"failed":0},"hits":{"hits":[%s]}}`,
+			gjson.GetBytes(body, "pit.id").String(), strings.Join(hits, ","))
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+func TestEsClient_DoPointInTimeRequestAllDocuments(t *testing.T) {
+	t.Parallel()
+
+	server := &pointInTimeServer{
+		ids:           []string{"erd1a", "erd1b", "erd1c", "erd1d", "erd1e"},
+		lostPitOnCall: 3,
+	}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	ec, err := NewElasticClient(data.EsClientConfig{Address: httpServer.URL})
+	require.Nil(t, err)
+	ec.pointInTimeRetryDelay = time.Millisecond
+
+	readIDs := make([]string, 0)
+	args := data.PointInTimeArgs{
+		AfterID:    "erd1",
+		PageSize:   2,
+		KeepAlive:  time.Minute,
+		MaxRetries: 2,
+	}
+	err = ec.DoPointInTimeRequestAllDocuments("accounts-000001", []byte(`{"query":{"match_all":{}}}`), args, func(responseBytes []byte) error {
+		for _, id := range gjson.GetBytes(responseBytes, "hits.hits.#._id").Array() {
+			readIDs = append(readIDs, id.String())
+		}
+		return nil
+	})
+	require.Nil(t, err)
+
+	require.Equal(t, server.ids, readIDs)
+	require.Equal(t, []string{"erd1", "erd1b", "erd1d"}, server.searchAfter)
+	require.Equal(t, 2, server.numOpened)
+	require.Equal(t, []string{"pit-1", "pit-2"}, server.closedPits)
+}
+
+func TestEsClient_DoPointInTimeRequestAllDocumentsShouldFailAfterMaxRetries(t *testing.T) {
+	t.Parallel()
+
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer httpServer.Close()
+
+	ec, _ := NewElasticClient(data.EsClientConfig{Address: httpServer.URL})
+	ec.pointInTimeRetryDelay = time.Millisecond
+
+	args := data.PointInTimeArgs{PageSize: 2, KeepAlive: time.Minute, MaxRetries: 1}
+	err := ec.DoPointInTimeRequestAllDocuments("accounts-000001", []byte(`{}`), args, func(_ []byte) error {
+		require.Fail(t, "should have not been called")
+		return nil
+	})
+	require.NotNil(t, err)
+}
@@ -33,3 +33,30 @@ func getDocumentsByIDsQueryEncoded(ids []string) *bytes.Buffer {
 
 	return encodedObj
 }
+
+func getMoveAliasQueryEncoded(alias string, oldIndices []string, newIndex string) (*bytes.Buffer, error) {
+	actions := make([]interface{}, 0, len(oldIndices)+1)
+	for _, oldIndex := range oldIndices {
+		if oldIndex == newIndex {
+			continue
+		}
+
+		actions = append(actions, objectsMap{
+			"remove": objectsMap{
+				"index": oldIndex,
+				"alias": alias,
+			},
+		})
+	}
+
+	actions = append(actions, objectsMap{
+		"add": objectsMap{
+			"index": newIndex,
+			"alias": alias,
+		},
+	})
+
+	return encode(objectsMap{
+		"actions": actions,
+	})
+}
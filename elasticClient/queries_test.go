@@ -0,0 +1,17 @@
+package elasticClient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetMoveAliasQueryEncoded(t *testing.T) {
+	t.Parallel()
+
+	buff, err := getMoveAliasQueryEncoded("accounts-with-stake", []string{"accounts-000001_10", "accounts-000001_11"}, "accounts-000001_11")
+	require.Nil(t, err)
+
+	expected := `{"actions":[{"remove":{"alias":"accounts-with-stake","index":"accounts-000001_10"}},{"add":{"alias":"accounts-with-stake","index":"accounts-000001_11"}}]}`
+	require.JSONEq(t, expected, buff.String())
+}
@@ -3,6 +3,7 @@ module github.com/multiversx/mx-chain-tools-accounts-manager-go
 go 1.17
 
 require (
+	github.com/btcsuite/btcd/btcutil v1.1.3
 	github.com/elastic/go-elasticsearch/v7 v7.12.0
 	github.com/multiversx/mx-chain-core-go v1.1.30
 	github.com/multiversx/mx-chain-es-indexer-go v1.3.8
@@ -14,7 +15,6 @@ require (
 )
 
 require (
-	github.com/btcsuite/btcd/btcutil v1.1.3 // indirect
 	github.com/cpuguy83/go-md2man/v2 v2.0.0-20190314233015-f79a8a8ca69d // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/denisbrodbeck/machineid v1.0.1 // indirect
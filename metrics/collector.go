@@ -0,0 +1,161 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	typeCounter = "counter"
+	typeGauge   = "gauge"
+)
+
+type sample struct {
+	labelValues []string
+	value       float64
+}
+
+// collector holds all the samples of a metric, one for every combination of label values
+type collector struct {
+	name       string
+	help       string
+	metricType string
+	labelNames []string
+
+	mutex   sync.RWMutex
+	samples map[string]*sample
+}
+
+func newCollector(name string, help string, metricType string, labelNames ...string) *collector {
+	return &collector{
+		name:       name,
+		help:       help,
+		metricType: metricType,
+		labelNames: labelNames,
+		samples:    make(map[string]*sample),
+	}
+}
+
+func (c *collector) add(value float64, labelValues ...string) {
+	c.mutex.Lock()
+	c.getOrCreateSample(labelValues).value += value
+	c.mutex.Unlock()
+}
+
+func (c *collector) set(value float64, labelValues ...string) {
+	c.mutex.Lock()
+	c.getOrCreateSample(labelValues).value = value
+	c.mutex.Unlock()
+}
+
+func (c *collector) get(labelValues ...string) float64 {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	s, ok := c.samples[sampleKey(labelValues)]
+	if !ok {
+		return 0
+	}
+
+	return s.value
+}
+
+func (c *collector) reset() {
+	c.mutex.Lock()
+	c.samples = make(map[string]*sample)
+	c.mutex.Unlock()
+}
+
+func (c *collector) getOrCreateSample(labelValues []string) *sample {
+	if len(labelValues) != len(c.labelNames) {
+		panic(fmt.Sprintf("metric %s expects %d label values, got %d", c.name, len(c.labelNames), len(labelValues)))
+	}
+
+	key := sampleKey(labelValues)
+	s, ok := c.samples[key]
+	if !ok {
+		s = &sample{labelValues: append([]string{}, labelValues...)}
+		c.samples[key] = s
+	}
+
+	return s
+}
+
+// writeText writes the metric in the Prometheus text exposition format. The samples are sorted by their label values
+func (c *collector) writeText(w io.Writer) error {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if len(c.samples) == 0 {
+		return nil
+	}
+
+	_, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", c.name, escapeHelp(c.help), c.name, c.metricType)
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(c.samples))
+	for key := range c.samples {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		s := c.samples[key]
+		_, err = fmt.Fprintf(w, "%s%s %s\n", c.name, c.formatLabels(s.labelValues), formatValue(s.value))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *collector) formatLabels(labelValues []string) string {
+	if len(c.labelNames) == 0 {
+		return ""
+	}
+
+	pairs := make([]string, 0, len(c.labelNames))
+	for idx, labelName := range c.labelNames {
+		pairs = append(pairs, fmt.Sprintf("%s=\"%s\"", labelName, escapeLabelValue(labelValues[idx])))
+	}
+
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func sampleKey(labelValues []string) string {
+	return strings.Join(labelValues, "\xff")
+}
+
+func formatValue(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	default:
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	}
+}
+
+var (
+	helpReplacer       = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelValueReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(help string) string {
+	return helpReplacer.Replace(help)
+}
+
+func escapeLabelValue(value string) string {
+	return labelValueReplacer.Replace(value)
+}
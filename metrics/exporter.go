@@ -0,0 +1,98 @@
+package metrics
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	logger "github.com/multiversx/mx-chain-logger-go"
+)
+
+const (
+	metricsRoute      = "/metrics"
+	textContentType   = "text/plain; version=0.0.4; charset=utf-8"
+	readHeaderTimeout = 10 * time.Second
+)
+
+var log = logger.GetOrCreate("metrics")
+
+// Handler will return the http handler that serves the metrics in the Prometheus text format
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		buff := &bytes.Buffer{}
+		err := WriteText(buff)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", textContentType)
+		_, _ = w.Write(buff.Bytes())
+	})
+}
+
+// StartServer will start serving the metrics on the /metrics route of the provided address. The listener is opened
+// before returning, so an address that is already in use is reported to the caller
+func StartServer(address string) (*http.Server, error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(metricsRoute, Handler())
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+
+	go func() {
+		errServe := server.Serve(listener)
+		if errServe != nil && !errors.Is(errServe, http.ErrServerClosed) {
+			log.Error("metrics server stopped", "error", errServe)
+		}
+	}()
+
+	log.Info("serving metrics", "address", listener.Addr().String(), "route", metricsRoute)
+
+	return server, nil
+}
+
+// WriteTextFile will write the metrics in the provided file, for the textfile collector of the node exporter. The file
+// is replaced atomically, so a scrape never reads a partially written file
+func WriteTextFile(path string) error {
+	buff := &bytes.Buffer{}
+	err := WriteText(buff)
+	if err != nil {
+		return err
+	}
+
+	tmpFile, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(tmpFile.Name())
+	}()
+
+	_, err = tmpFile.Write(buff.Bytes())
+	if err != nil {
+		_ = tmpFile.Close()
+		return err
+	}
+	err = tmpFile.Close()
+	if err != nil {
+		return err
+	}
+	err = os.Chmod(tmpFile.Name(), 0644)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmpFile.Name(), path)
+}
@@ -0,0 +1,156 @@
+package metrics
+
+import (
+	"io"
+	"time"
+)
+
+const namespace = "accounts_manager_"
+
+const (
+	// ResultSuccess labels a run that finished successfully
+	ResultSuccess = "success"
+	// ResultFailure labels a run that failed
+	ResultFailure = "failure"
+	// StatusNetworkError labels a REST request that did not get any response
+	StatusNetworkError = "error"
+)
+
+var (
+	runsTotal = newCollector(namespace+"runs_total",
+		"Number of processed epochs, by result", typeCounter, "result")
+	runDurationSeconds = newCollector(namespace+"run_duration_seconds",
+		"Duration of the last processed epoch, by result", typeGauge, "result")
+	lastSuccessfulEpoch = newCollector(namespace+"last_successful_epoch",
+		"The last epoch whose accounts were successfully processed", typeGauge)
+	lastSuccessTimestampSeconds = newCollector(namespace+"last_success_timestamp_seconds",
+		"Unix time of the last successfully processed epoch", typeGauge)
+
+	snapshotHealthy = newCollector(namespace+"snapshot_healthy",
+		"1 if the last accounts snapshot passed the sanity gate, 0 otherwise", typeGauge)
+
+	sourceFetchDurationSeconds = newCollector(namespace+"source_fetch_duration_seconds",
+		"Duration of the last fetch of a stake source", typeGauge, "source")
+	sourceAccounts = newCollector(namespace+"source_accounts",
+		"Number of accounts returned by the last successful fetch of a stake source", typeGauge, "source")
+	sourceFetchErrorsTotal = newCollector(namespace+"source_fetch_errors_total",
+		"Number of failed fetches of a stake source", typeCounter, "source")
+
+	restRequestsTotal = newCollector(namespace+"rest_requests_total",
+		"Number of REST requests sent to the gateways, by path and status", typeCounter, "path", "status")
+	restRetriesTotal = newCollector(namespace+"rest_retries_total",
+		"Number of retried REST requests, by path", typeCounter, "path")
+
+	vmQueriesInFlight = newCollector(namespace+"vm_queries_in_flight",
+		"Number of VM queries for the undelegated values of the validators that are in flight", typeGauge)
+	vmQueriesTotal = newCollector(namespace+"vm_queries_total",
+		"Number of VM queries for the undelegated values of the validators", typeCounter)
+
+	esScrollPagesTotal = newCollector(namespace+"es_scroll_pages_total",
+		"Number of scroll pages read from an Elasticsearch cluster", typeCounter, "cluster")
+	esBulkRequestsTotal = newCollector(namespace+"es_bulk_requests_total",
+		"Number of bulk requests sent to an Elasticsearch cluster", typeCounter, "cluster")
+	esBulkItemErrorsTotal = newCollector(namespace+"es_bulk_item_errors_total",
+		"Number of bulk items rejected by an Elasticsearch cluster", typeCounter, "cluster")
+	esBulkBytesTotal = newCollector(namespace+"es_bulk_bytes_total",
+		"Number of bytes sent in bulk requests to an Elasticsearch cluster", typeCounter, "cluster")
+
+	allCollectors = []*collector{
+		runsTotal,
+		runDurationSeconds,
+		lastSuccessfulEpoch,
+		lastSuccessTimestampSeconds,
+		snapshotHealthy,
+		sourceFetchDurationSeconds,
+		sourceAccounts,
+		sourceFetchErrorsTotal,
+		restRequestsTotal,
+		restRetriesTotal,
+		vmQueriesInFlight,
+		vmQueriesTotal,
+		esScrollPagesTotal,
+		esBulkRequestsTotal,
+		esBulkItemErrorsTotal,
+		esBulkBytesTotal,
+	}
+)
+
+// ObserveRun will record the result and the duration of a processed epoch
+func ObserveRun(epoch uint32, duration time.Duration, err error) {
+	if err != nil {
+		runsTotal.add(1, ResultFailure)
+		runDurationSeconds.set(duration.Seconds(), ResultFailure)
+		return
+	}
+
+	runsTotal.add(1, ResultSuccess)
+	runDurationSeconds.set(duration.Seconds(), ResultSuccess)
+	lastSuccessfulEpoch.set(float64(epoch))
+	lastSuccessTimestampSeconds.set(float64(time.Now().Unix()))
+}
+
+// SetSnapshotHealthy will record the result of the sanity gate for the last accounts snapshot
+func SetSnapshotHealthy(healthy bool) {
+	value := float64(0)
+	if healthy {
+		value = 1
+	}
+
+	snapshotHealthy.set(value)
+}
+
+// ObserveSourceFetch will record the duration and the number of accounts of a stake source fetch
+func ObserveSourceFetch(source string, duration time.Duration, numAccounts int, err error) {
+	sourceFetchDurationSeconds.set(duration.Seconds(), source)
+	if err != nil {
+		sourceFetchErrorsTotal.add(1, source)
+		return
+	}
+
+	sourceAccounts.set(float64(numAccounts), source)
+}
+
+// IncRestRequest will count a REST request attempt. The status is the HTTP status code or StatusNetworkError
+func IncRestRequest(path string, status string) {
+	restRequestsTotal.add(1, path, status)
+}
+
+// IncRestRetry will count a retried REST request
+func IncRestRetry(path string) {
+	restRetriesTotal.add(1, path)
+}
+
+// VMQueryStarted will mark the start of a VM query for the undelegated values of the validators
+func VMQueryStarted() {
+	vmQueriesTotal.add(1)
+	vmQueriesInFlight.add(1)
+}
+
+// VMQueryDone will mark the end of a VM query for the undelegated values of the validators
+func VMQueryDone() {
+	vmQueriesInFlight.add(-1)
+}
+
+// IncScrollPages will count a scroll page read from the provided cluster
+func IncScrollPages(cluster string) {
+	esScrollPagesTotal.add(1, cluster)
+}
+
+// ObserveBulkRequest will record a bulk request sent to the provided cluster
+func ObserveBulkRequest(cluster string, numBytes int, numItemErrors int) {
+	esBulkRequestsTotal.add(1, cluster)
+	esBulkBytesTotal.add(float64(numBytes), cluster)
+	esBulkItemErrorsTotal.add(float64(numItemErrors), cluster)
+}
+
+// WriteText will write all the metrics in the Prometheus text exposition format
+func WriteText(w io.Writer) error {
+	for _, c := range allCollectors {
+		err := c.writeText(w)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
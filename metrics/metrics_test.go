@@ -0,0 +1,114 @@
+package metrics
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollector_WriteText(t *testing.T) {
+	t.Parallel()
+
+	c := newCollector("test_requests_total", "Number of requests", typeCounter, "path", "status")
+	c.add(1, "/b", "200")
+	c.add(2, "/a", "500")
+	c.add(1, "/b", "200")
+	c.add(1, `/q"x`, "200")
+
+	buff := &bytes.Buffer{}
+	err := c.writeText(buff)
+	require.Nil(t, err)
+
+	expected := "# HELP test_requests_total Number of requests\n" +
+		"# TYPE test_requests_total counter\n" +
+		"test_requests_total{path=\"/a\",status=\"500\"} 2\n" +
+		"test_requests_total{path=\"/b\",status=\"200\"} 2\n" +
+		"test_requests_total{path=\"/q\\\"x\",status=\"200\"} 1\n"
+	require.Equal(t, expected, buff.String())
+}
+
+func TestCollector_WriteTextWithoutLabelsAndWithoutSamples(t *testing.T) {
+	t.Parallel()
+
+	c := newCollector("test_gauge", "A gauge", typeGauge)
+
+	buff := &bytes.Buffer{}
+	err := c.writeText(buff)
+	require.Nil(t, err)
+	require.Empty(t, buff.String())
+
+	c.set(12.5)
+	c.set(3)
+	err = c.writeText(buff)
+	require.Nil(t, err)
+	require.Equal(t, "# HELP test_gauge A gauge\n# TYPE test_gauge gauge\ntest_gauge 3\n", buff.String())
+}
+
+func TestCollector_WrongNumberOfLabelValuesShouldPanic(t *testing.T) {
+	t.Parallel()
+
+	c := newCollector("test_counter", "A counter", typeCounter, "source")
+	require.Panics(t, func() {
+		c.add(1)
+	})
+}
+
+func TestObserveSourceFetch(t *testing.T) {
+	t.Parallel()
+
+	ObserveSourceFetch("test-source-ok", 2*time.Second, 10, nil)
+	require.Equal(t, float64(2), sourceFetchDurationSeconds.get("test-source-ok"))
+	require.Equal(t, float64(10), sourceAccounts.get("test-source-ok"))
+	require.Equal(t, float64(0), sourceFetchErrorsTotal.get("test-source-ok"))
+
+	ObserveSourceFetch("test-source-err", time.Second, 0, errors.New("local error"))
+	ObserveSourceFetch("test-source-err", time.Second, 0, errors.New("local error"))
+	require.Equal(t, float64(2), sourceFetchErrorsTotal.get("test-source-err"))
+	require.Equal(t, float64(0), sourceAccounts.get("test-source-err"))
+}
+
+func TestObserveBulkRequest(t *testing.T) {
+	t.Parallel()
+
+	cluster := "http://test-bulk:9200"
+	ObserveBulkRequest(cluster, 100, 0)
+	ObserveBulkRequest(cluster, 50, 3)
+	IncScrollPages(cluster)
+
+	require.Equal(t, float64(2), esBulkRequestsTotal.get(cluster))
+	require.Equal(t, float64(150), esBulkBytesTotal.get(cluster))
+	require.Equal(t, float64(3), esBulkItemErrorsTotal.get(cluster))
+	require.Equal(t, float64(1), esScrollPagesTotal.get(cluster))
+}
+
+func TestHandlerAndWriteTextFile(t *testing.T) {
+	t.Parallel()
+
+	IncRestRequest("/test/handler", "200")
+
+	recorder := httptest.NewRecorder()
+	Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, metricsRoute, nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, textContentType, recorder.Header().Get("Content-Type"))
+	require.True(t, strings.Contains(recorder.Body.String(), `accounts_manager_rest_requests_total{path="/test/handler",status="200"}`))
+
+	path := filepath.Join(t.TempDir(), "accounts_manager.prom")
+	err := WriteTextFile(path)
+	require.Nil(t, err)
+
+	fileContent, err := ioutil.ReadFile(path)
+	require.Nil(t, err)
+	require.True(t, strings.Contains(string(fileContent), "# TYPE accounts_manager_rest_requests_total counter"))
+
+	matches, err := filepath.Glob(path + ".tmp*")
+	require.Nil(t, err)
+	require.Empty(t, matches)
+}
@@ -1,40 +1,56 @@
 package mocks
 
 import (
+	"context"
+
 	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
 )
 
+// AccountsGetterStub -
 type AccountsGetterStub struct {
-	GetLegacyDelegatorsAccountsCalled func() (map[string]*data.AccountInfoWithStakeValues, error)
-	GetValidatorsAccountsCalled       func() (map[string]*data.AccountInfoWithStakeValues, error)
-	GetDelegatorsAccountsCalled       func() (map[string]*data.AccountInfoWithStakeValues, error)
+	GetLegacyDelegatorsAccountsCalled func(ctx context.Context, snapshotBlock *data.SnapshotBlock) (map[string]*data.AccountInfoWithStakeValues, error)
+	GetValidatorsAccountsCalled       func(ctx context.Context, snapshotBlock *data.SnapshotBlock) (map[string]*data.AccountInfoWithStakeValues, error)
+	GetDelegatorsAccountsCalled       func(ctx context.Context, snapshotBlock *data.SnapshotBlock) (map[string]*data.AccountInfoWithStakeValues, error)
+	GetLKMEXStakeAccountsCalled       func(ctx context.Context, snapshotBlock *data.SnapshotBlock) (map[string]*data.AccountInfoWithStakeValues, error)
+	GetAccountsWithEnergyCalled       func(ctx context.Context, currentEpoch uint32, snapshotBlock *data.SnapshotBlock) (map[string]*data.AccountInfoWithStakeValues, *data.BlockInfo, error)
 }
 
-func (a *AccountsGetterStub) GetAccountsWithEnergy(_ uint32) (map[string]*data.AccountInfoWithStakeValues, *data.BlockInfo, error) {
+// GetAccountsWithEnergy -
+func (a *AccountsGetterStub) GetAccountsWithEnergy(ctx context.Context, currentEpoch uint32, snapshotBlock *data.SnapshotBlock) (map[string]*data.AccountInfoWithStakeValues, *data.BlockInfo, error) {
+	if a.GetAccountsWithEnergyCalled != nil {
+		return a.GetAccountsWithEnergyCalled(ctx, currentEpoch, snapshotBlock)
+	}
 	return nil, nil, nil
 }
 
-func (a *AccountsGetterStub) GetLKMEXStakeAccounts() (map[string]*data.AccountInfoWithStakeValues, error) {
+// GetLKMEXStakeAccounts -
+func (a *AccountsGetterStub) GetLKMEXStakeAccounts(ctx context.Context, snapshotBlock *data.SnapshotBlock) (map[string]*data.AccountInfoWithStakeValues, error) {
+	if a.GetLKMEXStakeAccountsCalled != nil {
+		return a.GetLKMEXStakeAccountsCalled(ctx, snapshotBlock)
+	}
 	return nil, nil
 }
 
-func (a *AccountsGetterStub) GetLegacyDelegatorsAccounts() (map[string]*data.AccountInfoWithStakeValues, error) {
+// GetLegacyDelegatorsAccounts -
+func (a *AccountsGetterStub) GetLegacyDelegatorsAccounts(ctx context.Context, snapshotBlock *data.SnapshotBlock) (map[string]*data.AccountInfoWithStakeValues, error) {
 	if a.GetLegacyDelegatorsAccountsCalled != nil {
-		return a.GetLegacyDelegatorsAccountsCalled()
+		return a.GetLegacyDelegatorsAccountsCalled(ctx, snapshotBlock)
 	}
 	return nil, nil
 }
 
-func (a *AccountsGetterStub) GetValidatorsAccounts() (map[string]*data.AccountInfoWithStakeValues, error) {
+// GetValidatorsAccounts -
+func (a *AccountsGetterStub) GetValidatorsAccounts(ctx context.Context, snapshotBlock *data.SnapshotBlock) (map[string]*data.AccountInfoWithStakeValues, error) {
 	if a.GetValidatorsAccountsCalled != nil {
-		return a.GetValidatorsAccountsCalled()
+		return a.GetValidatorsAccountsCalled(ctx, snapshotBlock)
 	}
 	return nil, nil
 }
 
-func (a *AccountsGetterStub) GetDelegatorsAccounts() (map[string]*data.AccountInfoWithStakeValues, error) {
+// GetDelegatorsAccounts -
+func (a *AccountsGetterStub) GetDelegatorsAccounts(ctx context.Context, snapshotBlock *data.SnapshotBlock) (map[string]*data.AccountInfoWithStakeValues, error) {
 	if a.GetDelegatorsAccountsCalled != nil {
-		return a.GetDelegatorsAccountsCalled()
+		return a.GetDelegatorsAccountsCalled(ctx, snapshotBlock)
 	}
 	return nil, nil
 }
@@ -0,0 +1,27 @@
+package mocks
+
+import "github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+
+// AccountsIndexerStub -
+type AccountsIndexerStub struct {
+	GetAccountsCalled       func(addresses []string, index string) (map[string]*data.AccountInfoWithStakeValues, error)
+	GetAccountsStrictCalled func(addresses []string, index string) (map[string]*data.AccountInfoWithStakeValues, error)
+}
+
+// GetAccounts -
+func (ais *AccountsIndexerStub) GetAccounts(addresses []string, index string) (map[string]*data.AccountInfoWithStakeValues, error) {
+	if ais.GetAccountsCalled != nil {
+		return ais.GetAccountsCalled(addresses, index)
+	}
+
+	return map[string]*data.AccountInfoWithStakeValues{}, nil
+}
+
+// GetAccountsStrict -
+func (ais *AccountsIndexerStub) GetAccountsStrict(addresses []string, index string) (map[string]*data.AccountInfoWithStakeValues, error) {
+	if ais.GetAccountsStrictCalled != nil {
+		return ais.GetAccountsStrictCalled(addresses, index)
+	}
+
+	return map[string]*data.AccountInfoWithStakeValues{}, nil
+}
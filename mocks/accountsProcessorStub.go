@@ -0,0 +1,52 @@
+package mocks
+
+import "github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+
+// AccountsProcessorStub -
+type AccountsProcessorStub struct {
+	GetCurrentEpochCalled            func() (uint32, error)
+	GetCurrentEpochStatusCalled      func() (*data.EpochStatus, error)
+	GetAllAccountsWithStakeCalled    func(epoch uint32) (*data.AccountsData, error)
+	ComputeClonedAccountsIndexCalled func(epoch uint32) (string, error)
+}
+
+// GetCurrentEpoch -
+func (aps *AccountsProcessorStub) GetCurrentEpoch() (uint32, error) {
+	if aps.GetCurrentEpochCalled != nil {
+		return aps.GetCurrentEpochCalled()
+	}
+
+	return 0, nil
+}
+
+// GetCurrentEpochStatus -
+func (aps *AccountsProcessorStub) GetCurrentEpochStatus() (*data.EpochStatus, error) {
+	if aps.GetCurrentEpochStatusCalled != nil {
+		return aps.GetCurrentEpochStatusCalled()
+	}
+
+	return &data.EpochStatus{}, nil
+}
+
+// GetAllAccountsWithStake -
+func (aps *AccountsProcessorStub) GetAllAccountsWithStake(epoch uint32) (*data.AccountsData, error) {
+	if aps.GetAllAccountsWithStakeCalled != nil {
+		return aps.GetAllAccountsWithStakeCalled(epoch)
+	}
+
+	return &data.AccountsData{}, nil
+}
+
+// ComputeClonedAccountsIndex -
+func (aps *AccountsProcessorStub) ComputeClonedAccountsIndex(epoch uint32) (string, error) {
+	if aps.ComputeClonedAccountsIndexCalled != nil {
+		return aps.ComputeClonedAccountsIndexCalled(epoch)
+	}
+
+	return "", nil
+}
+
+// IsInterfaceNil -
+func (aps *AccountsProcessorStub) IsInterfaceNil() bool {
+	return aps == nil
+}
@@ -0,0 +1,52 @@
+package mocks
+
+import "github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+
+// DataProcessorStub -
+type DataProcessorStub struct {
+	ProcessAccountsDataCalled         func() error
+	ProcessAccountsDataForEpochCalled func(epoch uint32) error
+	GetCurrentEpochStatusCalled       func() (*data.EpochStatus, error)
+	IsEpochProcessedCalled            func(epoch uint32) (bool, error)
+}
+
+// ProcessAccountsData -
+func (d *DataProcessorStub) ProcessAccountsData() error {
+	if d.ProcessAccountsDataCalled != nil {
+		return d.ProcessAccountsDataCalled()
+	}
+
+	return nil
+}
+
+// ProcessAccountsDataForEpoch -
+func (d *DataProcessorStub) ProcessAccountsDataForEpoch(epoch uint32) error {
+	if d.ProcessAccountsDataForEpochCalled != nil {
+		return d.ProcessAccountsDataForEpochCalled(epoch)
+	}
+
+	return nil
+}
+
+// GetCurrentEpochStatus -
+func (d *DataProcessorStub) GetCurrentEpochStatus() (*data.EpochStatus, error) {
+	if d.GetCurrentEpochStatusCalled != nil {
+		return d.GetCurrentEpochStatusCalled()
+	}
+
+	return &data.EpochStatus{}, nil
+}
+
+// IsEpochProcessed -
+func (d *DataProcessorStub) IsEpochProcessed(epoch uint32) (bool, error) {
+	if d.IsEpochProcessedCalled != nil {
+		return d.IsEpochProcessedCalled(epoch)
+	}
+
+	return false, nil
+}
+
+// IsInterfaceNil -
+func (d *DataProcessorStub) IsInterfaceNil() bool {
+	return d == nil
+}
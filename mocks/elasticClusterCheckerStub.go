@@ -0,0 +1,20 @@
+package mocks
+
+// ElasticClusterCheckerStub -
+type ElasticClusterCheckerStub struct {
+	CheckConnectionCalled func() error
+}
+
+// CheckConnection -
+func (ecs *ElasticClusterCheckerStub) CheckConnection() error {
+	if ecs.CheckConnectionCalled != nil {
+		return ecs.CheckConnectionCalled()
+	}
+
+	return nil
+}
+
+// IsInterfaceNil -
+func (ecs *ElasticClusterCheckerStub) IsInterfaceNil() bool {
+	return ecs == nil
+}
@@ -2,11 +2,88 @@ package mocks
 
 import (
 	"bytes"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
 )
 
 // ElasticClientStub -
 type ElasticClientStub struct {
-	DoScrollRequestAllDocumentsCalled func(index string, body []byte, handlerFunc func(responseBytes []byte) error) error
+	PutPolicyCalled                        func(policyName string, policy *bytes.Buffer) error
+	GetPolicyCalled                        func(policyName string) ([]byte, error)
+	CreateIndexWithMappingCalled           func(index string, mapping *bytes.Buffer) error
+	CheckIfIndexExistsCalled               func(index string) (bool, error)
+	GetAliasIndicesCalled                  func(alias string) ([]string, error)
+	DoRequestCalled                        func(index string, documentID string, buff *bytes.Buffer) error
+	DoMultiGetCalled                       func(ids []string, index string) ([]byte, error)
+	DoBulkRequestCalled                    func(buff *bytes.Buffer, index string) error
+	DoBulkRequestWithItemsCalled           func(buff *bytes.Buffer, index string) (*data.BulkRequestResponse, error)
+	PutIndexTemplateCalled                 func(templateName string, template *bytes.Buffer) error
+	DoScrollRequestAllDocumentsCalled      func(index string, body []byte, handlerFunc func(responseBytes []byte) error) error
+	DoPointInTimeRequestAllDocumentsCalled func(index string, body []byte, args data.PointInTimeArgs, handlerFunc func(responseBytes []byte) error) error
+	DoSearchRequestCalled                  func(index string, body []byte) ([]byte, error)
+	RefreshIndexCalled                     func(index string) error
+	GetIndicesCalled                       func(pattern string) ([]string, error)
+	DeleteIndexCalled                      func(index string) error
+	DeleteByQueryCalled                    func(index string, body []byte) error
+}
+
+// PutPolicy -
+func (e *ElasticClientStub) PutPolicy(policyName string, policy *bytes.Buffer) error {
+	if e.PutPolicyCalled != nil {
+		return e.PutPolicyCalled(policyName, policy)
+	}
+
+	return nil
+}
+
+// GetPolicy -
+func (e *ElasticClientStub) GetPolicy(policyName string) ([]byte, error) {
+	if e.GetPolicyCalled != nil {
+		return e.GetPolicyCalled(policyName)
+	}
+
+	return nil, nil
+}
+
+// CreateIndexWithMapping -
+func (e *ElasticClientStub) CreateIndexWithMapping(index string, mapping *bytes.Buffer) error {
+	if e.CreateIndexWithMappingCalled != nil {
+		return e.CreateIndexWithMappingCalled(index, mapping)
+	}
+
+	return nil
+}
+
+// CheckIfIndexExists -
+func (e *ElasticClientStub) CheckIfIndexExists(index string) (bool, error) {
+	if e.CheckIfIndexExistsCalled != nil {
+		return e.CheckIfIndexExistsCalled(index)
+	}
+
+	return false, nil
+}
+
+// GetAliasIndices -
+func (e *ElasticClientStub) GetAliasIndices(alias string) ([]string, error) {
+	if e.GetAliasIndicesCalled != nil {
+		return e.GetAliasIndicesCalled(alias)
+	}
+
+	return []string{}, nil
+}
+
+// UpdateAliases -
+func (e *ElasticClientStub) UpdateAliases(_ string, _ []string, _ string) error {
+	panic("implement me")
+}
+
+// DoRequest -
+func (e *ElasticClientStub) DoRequest(index, documentID string, buff *bytes.Buffer) error {
+	if e.DoRequestCalled != nil {
+		return e.DoRequestCalled(index, documentID, buff)
+	}
+
+	return nil
 }
 
 // PutMapping -
@@ -15,13 +92,42 @@ func (e *ElasticClientStub) PutMapping(_ string, _ *bytes.Buffer) error {
 }
 
 // DoBulkRequest -
-func (e *ElasticClientStub) DoBulkRequest(_ *bytes.Buffer, _ string) error {
-	panic("implement me")
+func (e *ElasticClientStub) DoBulkRequest(buff *bytes.Buffer, index string) error {
+	if e.DoBulkRequestCalled != nil {
+		return e.DoBulkRequestCalled(buff, index)
+	}
+
+	return nil
+}
+
+// DoBulkRequestWithItems -
+func (e *ElasticClientStub) DoBulkRequestWithItems(buff *bytes.Buffer, index string) (*data.BulkRequestResponse, error) {
+	if e.DoBulkRequestWithItemsCalled != nil {
+		return e.DoBulkRequestWithItemsCalled(buff, index)
+	}
+	if e.DoBulkRequestCalled != nil {
+		return &data.BulkRequestResponse{}, e.DoBulkRequestCalled(buff, index)
+	}
+
+	return &data.BulkRequestResponse{}, nil
+}
+
+// PutIndexTemplate -
+func (e *ElasticClientStub) PutIndexTemplate(templateName string, template *bytes.Buffer) error {
+	if e.PutIndexTemplateCalled != nil {
+		return e.PutIndexTemplateCalled(templateName, template)
+	}
+
+	return nil
 }
 
 // DoMultiGet -
-func (e *ElasticClientStub) DoMultiGet(_ []string, _ string) ([]byte, error) {
-	panic("implement me")
+func (e *ElasticClientStub) DoMultiGet(ids []string, index string) ([]byte, error) {
+	if e.DoMultiGetCalled != nil {
+		return e.DoMultiGetCalled(ids, index)
+	}
+
+	return nil, nil
 }
 
 // DoScrollRequestAllDocuments -
@@ -33,6 +139,60 @@ func (e *ElasticClientStub) DoScrollRequestAllDocuments(index string, body []byt
 	return nil
 }
 
+// DoPointInTimeRequestAllDocuments -
+func (e *ElasticClientStub) DoPointInTimeRequestAllDocuments(index string, body []byte, args data.PointInTimeArgs, handlerFunc func(responseBytes []byte) error) error {
+	if e.DoPointInTimeRequestAllDocumentsCalled != nil {
+		return e.DoPointInTimeRequestAllDocumentsCalled(index, body, args, handlerFunc)
+	}
+
+	return nil
+}
+
+// DoSearchRequest -
+func (e *ElasticClientStub) DoSearchRequest(index string, body []byte) ([]byte, error) {
+	if e.DoSearchRequestCalled != nil {
+		return e.DoSearchRequestCalled(index, body)
+	}
+
+	return nil, nil
+}
+
+// RefreshIndex -
+func (e *ElasticClientStub) RefreshIndex(index string) error {
+	if e.RefreshIndexCalled != nil {
+		return e.RefreshIndexCalled(index)
+	}
+
+	return nil
+}
+
+// GetIndices -
+func (e *ElasticClientStub) GetIndices(pattern string) ([]string, error) {
+	if e.GetIndicesCalled != nil {
+		return e.GetIndicesCalled(pattern)
+	}
+
+	return nil, nil
+}
+
+// DeleteIndex -
+func (e *ElasticClientStub) DeleteIndex(index string) error {
+	if e.DeleteIndexCalled != nil {
+		return e.DeleteIndexCalled(index)
+	}
+
+	return nil
+}
+
+// DeleteByQuery -
+func (e *ElasticClientStub) DeleteByQuery(index string, body []byte) error {
+	if e.DeleteByQueryCalled != nil {
+		return e.DeleteByQueryCalled(index, body)
+	}
+
+	return nil
+}
+
 // IsInterfaceNil -
 func (e *ElasticClientStub) IsInterfaceNil() bool {
 	return e == nil
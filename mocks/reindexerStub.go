@@ -0,0 +1,42 @@
+package mocks
+
+import "github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+
+// ReindexerStub -
+type ReindexerStub struct {
+	ReindexAccountsCalled func(sourceIndex string, destinationIndex string, accountsData *data.AccountsData, report *data.RunReport) error
+	IndexRunReportCalled  func(report *data.RunReport) error
+	WasReindexedCalled    func(destinationIndex string, epoch uint32) (bool, error)
+}
+
+// ReindexAccounts -
+func (rs *ReindexerStub) ReindexAccounts(sourceIndex string, destinationIndex string, accountsData *data.AccountsData, report *data.RunReport) error {
+	if rs.ReindexAccountsCalled != nil {
+		return rs.ReindexAccountsCalled(sourceIndex, destinationIndex, accountsData, report)
+	}
+
+	return nil
+}
+
+// IndexRunReport -
+func (rs *ReindexerStub) IndexRunReport(report *data.RunReport) error {
+	if rs.IndexRunReportCalled != nil {
+		return rs.IndexRunReportCalled(report)
+	}
+
+	return nil
+}
+
+// WasReindexed -
+func (rs *ReindexerStub) WasReindexed(destinationIndex string, epoch uint32) (bool, error) {
+	if rs.WasReindexedCalled != nil {
+		return rs.WasReindexedCalled(destinationIndex, epoch)
+	}
+
+	return false, nil
+}
+
+// IsInterfaceNil -
+func (rs *ReindexerStub) IsInterfaceNil() bool {
+	return rs == nil
+}
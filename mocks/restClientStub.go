@@ -1,26 +1,36 @@
 package mocks
 
-import "github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+import (
+	"context"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+)
 
 // RestClientStub -
 type RestClientStub struct {
-	CallPostRestEndPointCalled func(path string, data interface{}, response interface{}, authenticationData data.RestApiAuthenticationData) error
+	CallGetRestEndPointCalled  func(ctx context.Context, path string, value interface{}, authenticationData data.RestApiAuthenticationData) error
+	CallPostRestEndPointCalled func(ctx context.Context, path string, data interface{}, response interface{}, authenticationData data.RestApiAuthenticationData) error
 }
 
 // CallGetRestEndPoint -
-func (r RestClientStub) CallGetRestEndPoint(_ string, _ interface{}, _ data.RestApiAuthenticationData) error {
+func (r RestClientStub) CallGetRestEndPoint(ctx context.Context, path string, value interface{}, authenticationData data.RestApiAuthenticationData) error {
+	if r.CallGetRestEndPointCalled != nil {
+		return r.CallGetRestEndPointCalled(ctx, path, value, authenticationData)
+	}
+
 	panic("implement me")
 }
 
 // CallPostRestEndPoint -
 func (r RestClientStub) CallPostRestEndPoint(
+	ctx context.Context,
 	path string,
 	data interface{},
 	response interface{},
 	authenticationData data.RestApiAuthenticationData,
 ) error {
 	if r.CallPostRestEndPointCalled != nil {
-		return r.CallPostRestEndPointCalled(path, data, response, authenticationData)
+		return r.CallPostRestEndPointCalled(ctx, path, data, response, authenticationData)
 	}
 
 	return nil
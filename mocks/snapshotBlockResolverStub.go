@@ -0,0 +1,26 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+)
+
+// SnapshotBlockResolverStub -
+type SnapshotBlockResolverStub struct {
+	ResolveSnapshotBlockCalled func(ctx context.Context, epoch uint32) (*data.SnapshotBlock, error)
+}
+
+// ResolveSnapshotBlock -
+func (s *SnapshotBlockResolverStub) ResolveSnapshotBlock(ctx context.Context, epoch uint32) (*data.SnapshotBlock, error) {
+	if s.ResolveSnapshotBlockCalled != nil {
+		return s.ResolveSnapshotBlockCalled(ctx, epoch)
+	}
+
+	return nil, nil
+}
+
+// IsInterfaceNil -
+func (s *SnapshotBlockResolverStub) IsInterfaceNil() bool {
+	return s == nil
+}
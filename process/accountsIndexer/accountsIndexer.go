@@ -1,12 +1,12 @@
 package accountsIndexer
 
 import (
-	"bytes"
 	"encoding/json"
 	"fmt"
 
-	dataIndexer "github.com/multiversx/mx-chain-es-indexer-go/data"
+	"github.com/multiversx/mx-chain-core-go/core/check"
 	logger "github.com/multiversx/mx-chain-logger-go"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
 	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
 	"github.com/tidwall/gjson"
 )
@@ -19,17 +19,39 @@ var log = logger.GetOrCreate("process/accountsIndexer")
 
 type accountsIndexer struct {
 	elasticClient ElasticClientHandler
+	bulkSettings  bulkIndexerSettings
 }
 
-// NewAccountsIndexer will create a new instance of accountsIndexer
-func NewAccountsIndexer(elasticClient ElasticClientHandler) (*accountsIndexer, error) {
+// NewAccountsIndexer will create a new instance of accountsIndexer. The zero values of the bulk indexer config are
+// replaced with defaults
+func NewAccountsIndexer(elasticClient ElasticClientHandler, bulkIndexerConfig config.BulkIndexerConfig) (*accountsIndexer, error) {
+	if check.IfNil(elasticClient) {
+		return nil, ErrNilElasticClient
+	}
+
+	bulkSettings, err := newBulkIndexerSettings(bulkIndexerConfig)
+	if err != nil {
+		return nil, err
+	}
+
 	return &accountsIndexer{
 		elasticClient: elasticClient,
+		bulkSettings:  bulkSettings,
 	}, nil
 }
 
-// GetAccounts will get accounts by addresses from a given index
+// GetAccounts will get accounts by addresses from a given index. A bulk of addresses that cannot be read is skipped
 func (ai *accountsIndexer) GetAccounts(addresses []string, index string) (map[string]*data.AccountInfoWithStakeValues, error) {
+	return ai.getAccounts(addresses, index, false)
+}
+
+// GetAccountsStrict will get accounts by addresses from a given index. It returns an error if a bulk of addresses cannot
+// be read, so that a missing account is not mistaken for one that does not exist
+func (ai *accountsIndexer) GetAccountsStrict(addresses []string, index string) (map[string]*data.AccountInfoWithStakeValues, error) {
+	return ai.getAccounts(addresses, index, true)
+}
+
+func (ai *accountsIndexer) getAccounts(addresses []string, index string, strict bool) (map[string]*data.AccountInfoWithStakeValues, error) {
 	accountsES := make(map[string]*data.AccountInfoWithStakeValues)
 	for idx := 0; idx < len(addresses); idx += numAddressesInBulk {
 		from := idx
@@ -45,6 +67,9 @@ func (ai *accountsIndexer) GetAccounts(addresses []string, index string) (map[st
 
 		copy(newSliceOfAddresses, addresses[from:to])
 		accounts, errGet := ai.getBulkOfAccounts(newSliceOfAddresses, index)
+		if errGet != nil && strict {
+			return nil, fmt.Errorf("%w from index %s: %s", ErrCannotGetAccounts, index, errGet.Error())
+		}
 		if errGet != nil {
 			log.Warn("accountsIndexer.GetAccounts: cannot get accounts", "error", errGet)
 			continue
@@ -81,37 +106,40 @@ func (ai *accountsIndexer) getBulkOfAccounts(addresses []string, index string) (
 	return accounts, nil
 }
 
-// IndexAccounts will index provided accounts in a given index
+// IndexAccounts will index provided accounts in a given index. The documents that failed because the cluster was
+// overloaded are retried, an error listing the addresses of the rejected accounts is returned
 func (ai *accountsIndexer) IndexAccounts(accounts map[string]*data.AccountInfoWithStakeValues, index string) error {
-	buffSlice, err := serializeAccounts(accounts)
-	if err != nil {
-		return err
-	}
-	for idx := range buffSlice {
-		err = ai.elasticClient.DoBulkRequest(buffSlice[idx], index)
+	bi := newBulkIndexer(ai.elasticClient, index, ai.bulkSettings)
+	for address, acc := range accounts {
+		meta, serializedData, err := prepareSerializedAccountInfo(address, acc)
 		if err != nil {
+			_ = bi.close()
 			return err
 		}
+
+		bi.add(address, meta, serializedData)
 	}
 
-	return nil
+	return bi.close()
 }
 
-func serializeAccounts(accounts map[string]*data.AccountInfoWithStakeValues) ([]*bytes.Buffer, error) {
-	buffSlice := dataIndexer.NewBufferSlice(0)
-	for address, acc := range accounts {
-		meta, serializedData, err := prepareSerializedAccountInfo(address, acc)
+// IndexStakeHistory will index the provided stake history documents in a given index. The documents are identified by
+// address and epoch
+func (ai *accountsIndexer) IndexStakeHistory(entries []*data.AccountStakeHistory, index string) error {
+	bi := newBulkIndexer(ai.elasticClient, index, ai.bulkSettings)
+	for _, entry := range entries {
+		id := fmt.Sprintf("%s_%d", entry.Address, entry.Epoch)
+		meta := []byte(fmt.Sprintf(`{ "index" : { "_id" : "%s" } }%s`, id, "\n"))
+		serializedData, err := json.Marshal(entry)
 		if err != nil {
-			return nil, err
+			_ = bi.close()
+			return err
 		}
 
-		err = buffSlice.PutData(meta, serializedData)
-		if err != nil {
-			return nil, err
-		}
+		bi.add(id, meta, serializedData)
 	}
 
-	return buffSlice.Buffers(), nil
+	return bi.close()
 }
 
 func prepareSerializedAccountInfo(
@@ -0,0 +1,56 @@
+package accountsIndexer
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func createMultiGetStub(failingAddress string) *mocks.ElasticClientStub {
+	return &mocks.ElasticClientStub{
+		DoMultiGetCalled: func(ids []string, _ string) ([]byte, error) {
+			docs := make([]string, 0, len(ids))
+			for _, id := range ids {
+				if id == failingAddress {
+					return nil, errors.New("cluster unavailable")
+				}
+				docs = append(docs, fmt.Sprintf(`{"_id":"%s","found":true,"_source":{"address":"%s"}}`, id, id))
+			}
+			return []byte(fmt.Sprintf(`{"docs":[%s]}`, strings.Join(docs, ","))), nil
+		},
+	}
+}
+
+func TestAccountsIndexer_GetAccountsShouldSkipTheFailedBulks(t *testing.T) {
+	t.Parallel()
+
+	addresses := make([]string, 0, numAddressesInBulk+1)
+	for idx := 0; idx < numAddressesInBulk+1; idx++ {
+		addresses = append(addresses, fmt.Sprintf("erd1%d", idx))
+	}
+
+	ai, _ := NewAccountsIndexer(createMultiGetStub("erd10"), config.BulkIndexerConfig{})
+	accounts, err := ai.GetAccounts(addresses, "accounts-000001")
+	require.Nil(t, err)
+	require.Len(t, accounts, 1)
+	require.Equal(t, addresses[numAddressesInBulk], accounts[addresses[numAddressesInBulk]].Address)
+}
+
+func TestAccountsIndexer_GetAccountsStrict(t *testing.T) {
+	t.Parallel()
+
+	ai, _ := NewAccountsIndexer(createMultiGetStub("erd1b"), config.BulkIndexerConfig{})
+	accounts, err := ai.GetAccountsStrict([]string{"erd1a"}, "accounts-000001")
+	require.Nil(t, err)
+	require.Len(t, accounts, 1)
+
+	accounts, err = ai.GetAccountsStrict([]string{"erd1a", "erd1b"}, "accounts-000001")
+	require.Nil(t, accounts)
+	require.True(t, errors.Is(err, ErrCannotGetAccounts))
+	require.Contains(t, err.Error(), "cluster unavailable")
+}
@@ -0,0 +1,331 @@
+package accountsIndexer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	dataIndexer "github.com/multiversx/mx-chain-es-indexer-go/data"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+)
+
+const (
+	defaultNumWorkers            = 2
+	defaultFlushDocuments        = 1000
+	defaultMaxRetries            = 3
+	defaultInitialBackoffInMilli = 500
+	defaultMaxBackoffInSeconds   = 30
+
+	// numRejectedToDescribe is the number of rejected documents described in the returned error, all of them are logged
+	numRejectedToDescribe = 10
+)
+
+// RejectedDocument holds a document that was permanently rejected by the elastic cluster
+type RejectedDocument struct {
+	ID     string
+	Status int
+	Type   string
+	Reason string
+}
+
+type bulkItem struct {
+	id   string
+	meta []byte
+	body []byte
+}
+
+type bulkIndexerSettings struct {
+	numWorkers     int
+	flushBytes     int
+	flushDocuments int
+	maxRetries     int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+}
+
+// bulkIndexer sends the added documents in bulk requests, from a fixed number of workers. A batch is flushed when it
+// reaches the configured number of documents or bytes, and adding documents blocks while all the workers are busy.
+// Only the items that failed with a retriable status are sent again, the other failures are reported as rejected
+type bulkIndexer struct {
+	elasticClient ElasticClientHandler
+	index         string
+	settings      bulkIndexerSettings
+
+	batches      chan []*bulkItem
+	currentBatch []*bulkItem
+	currentBytes int
+	wg           sync.WaitGroup
+
+	mut        sync.Mutex
+	rejected   []*RejectedDocument
+	numIndexed int
+	numRetries int
+}
+
+func newBulkIndexerSettings(cfg config.BulkIndexerConfig) (bulkIndexerSettings, error) {
+	if cfg.NumWorkers < 0 || cfg.FlushBytes < 0 || cfg.FlushDocuments < 0 || cfg.MaxRetries < 0 ||
+		cfg.InitialBackoffInMilliseconds < 0 || cfg.MaxBackoffInSeconds < 0 {
+		return bulkIndexerSettings{}, fmt.Errorf("%w: negative values are not allowed", ErrInvalidBulkIndexerConfig)
+	}
+
+	settings := bulkIndexerSettings{
+		numWorkers:     cfg.NumWorkers,
+		flushBytes:     cfg.FlushBytes,
+		flushDocuments: cfg.FlushDocuments,
+		maxRetries:     cfg.MaxRetries,
+		initialBackoff: time.Duration(cfg.InitialBackoffInMilliseconds) * time.Millisecond,
+		maxBackoff:     time.Duration(cfg.MaxBackoffInSeconds) * time.Second,
+	}
+	if settings.numWorkers == 0 {
+		settings.numWorkers = defaultNumWorkers
+	}
+	if settings.flushBytes == 0 {
+		settings.flushBytes = dataIndexer.DefaultMaxBulkSize
+	}
+	if settings.flushDocuments == 0 {
+		settings.flushDocuments = defaultFlushDocuments
+	}
+	if settings.maxRetries == 0 {
+		settings.maxRetries = defaultMaxRetries
+	}
+	if settings.initialBackoff == 0 {
+		settings.initialBackoff = defaultInitialBackoffInMilli * time.Millisecond
+	}
+	if settings.maxBackoff == 0 {
+		settings.maxBackoff = defaultMaxBackoffInSeconds * time.Second
+	}
+
+	return settings, nil
+}
+
+func newBulkIndexer(elasticClient ElasticClientHandler, index string, settings bulkIndexerSettings) *bulkIndexer {
+	bi := &bulkIndexer{
+		elasticClient: elasticClient,
+		index:         index,
+		settings:      settings,
+		batches:       make(chan []*bulkItem),
+	}
+
+	bi.wg.Add(settings.numWorkers)
+	for idx := 0; idx < settings.numWorkers; idx++ {
+		go bi.runWorker()
+	}
+
+	return bi
+}
+
+func (bi *bulkIndexer) runWorker() {
+	defer bi.wg.Done()
+
+	for batch := range bi.batches {
+		bi.indexBatch(batch)
+	}
+}
+
+// add will add the provided document in the current batch. The current batch is flushed first if the document does
+// not fit in it
+func (bi *bulkIndexer) add(id string, meta []byte, body []byte) {
+	itemBytes := len(meta) + len(body) + 1
+	if len(bi.currentBatch) > 0 && bi.currentBytes+itemBytes > bi.settings.flushBytes {
+		bi.flush()
+	}
+
+	bi.currentBatch = append(bi.currentBatch, &bulkItem{id: id, meta: meta, body: body})
+	bi.currentBytes += itemBytes
+
+	if len(bi.currentBatch) >= bi.settings.flushDocuments {
+		bi.flush()
+	}
+}
+
+// flush will hand the current batch to a worker, waiting for one to be free
+func (bi *bulkIndexer) flush() {
+	if len(bi.currentBatch) == 0 {
+		return
+	}
+
+	bi.batches <- bi.currentBatch
+	bi.currentBatch = nil
+	bi.currentBytes = 0
+}
+
+// close will flush the current batch and will wait for all the batches to be indexed. An error listing the rejected
+// documents is returned if some of them could not be indexed
+func (bi *bulkIndexer) close() error {
+	bi.flush()
+	close(bi.batches)
+	bi.wg.Wait()
+
+	bi.mut.Lock()
+	defer bi.mut.Unlock()
+
+	log.Debug("bulk indexer finished", "index", bi.index, "indexed", bi.numIndexed, "retries", bi.numRetries, "rejected", len(bi.rejected))
+	if len(bi.rejected) == 0 {
+		return nil
+	}
+
+	for _, doc := range bi.rejected {
+		log.Warn("document rejected by the elastic cluster", "index", bi.index, "id", doc.ID, "status", doc.Status, "type", doc.Type, "reason", doc.Reason)
+	}
+
+	return fmt.Errorf("%w: %d documents in index %s: %s", ErrRejectedDocuments, len(bi.rejected), bi.index, describeRejectedDocuments(bi.rejected))
+}
+
+func (bi *bulkIndexer) indexBatch(batch []*bulkItem) {
+	pending := batch
+	for attempt := 0; ; attempt++ {
+		var rejected []*RejectedDocument
+		pending, rejected = bi.sendBatch(pending, attempt == bi.settings.maxRetries)
+		bi.addRejected(rejected)
+		if len(pending) == 0 {
+			return
+		}
+
+		bi.mut.Lock()
+		bi.numRetries++
+		bi.mut.Unlock()
+
+		delay := bi.backoff(attempt)
+		log.Debug("retrying the failed documents of a bulk request", "index", bi.index, "documents", len(pending), "delay", delay)
+		time.Sleep(delay)
+	}
+}
+
+// sendBatch will send the provided items in a bulk request and will return the items that should be retried and the
+// rejected ones. On the last attempt, no item is retried anymore
+func (bi *bulkIndexer) sendBatch(items []*bulkItem, isLastAttempt bool) ([]*bulkItem, []*RejectedDocument) {
+	buff := &bytes.Buffer{}
+	for _, item := range items {
+		buff.Grow(len(item.meta) + len(item.body) + 1)
+		_, _ = buff.Write(item.meta)
+		_, _ = buff.Write(item.body)
+		_, _ = buff.Write([]byte("\n"))
+	}
+
+	response, err := bi.elasticClient.DoBulkRequestWithItems(buff, bi.index)
+	if err == nil && response.Errors && len(response.Items) != len(items) {
+		err = fmt.Errorf("unexpected number of items in the bulk response: %d instead of %d", len(response.Items), len(items))
+	}
+	if err != nil {
+		if isLastAttempt || isRefusedRequest(err) {
+			return nil, rejectAll(items, err)
+		}
+
+		log.Warn("bulk request failed", "index", bi.index, "documents", len(items), "error", err)
+		return items, nil
+	}
+	if !response.Errors {
+		bi.addIndexed(len(items))
+		return nil, nil
+	}
+
+	retry := make([]*bulkItem, 0)
+	rejected := make([]*RejectedDocument, 0)
+	for idx, responseItem := range response.Items {
+		status := responseItem.Index.Status
+		if status < http.StatusMultipleChoices {
+			continue
+		}
+		if isRetriableItemStatus(status) && !isLastAttempt {
+			retry = append(retry, items[idx])
+			continue
+		}
+
+		rejected = append(rejected, &RejectedDocument{
+			ID:     items[idx].id,
+			Status: status,
+			Type:   responseItem.Index.Error.Type,
+			Reason: responseItem.Index.Error.Reason,
+		})
+	}
+	bi.addIndexed(len(items) - len(retry) - len(rejected))
+
+	return retry, rejected
+}
+
+// backoff returns the delay before the next attempt. The delay grows exponentially with the number of attempts, it is
+// capped to the max backoff and half of it is random, so that the workers do not retry in lockstep
+func (bi *bulkIndexer) backoff(attempt int) time.Duration {
+	delay := bi.settings.maxBackoff
+	if attempt < 32 {
+		exponential := bi.settings.initialBackoff << uint(attempt)
+		if exponential > 0 && exponential < bi.settings.maxBackoff {
+			delay = exponential
+		}
+	}
+
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+func (bi *bulkIndexer) addIndexed(numIndexed int) {
+	bi.mut.Lock()
+	bi.numIndexed += numIndexed
+	bi.mut.Unlock()
+}
+
+func (bi *bulkIndexer) addRejected(rejected []*RejectedDocument) {
+	if len(rejected) == 0 {
+		return
+	}
+
+	bi.mut.Lock()
+	bi.rejected = append(bi.rejected, rejected...)
+	bi.mut.Unlock()
+}
+
+// isRetriableItemStatus returns true for the statuses of the items rejected because the cluster was overloaded, such
+// as 429 es_rejected_execution_exception
+func isRetriableItemStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
+
+// isRefusedRequest returns true if the cluster refused the whole bulk request with a status that will not change on a
+// retry, such as 400 for a malformed request or 401 and 403 for wrong credentials
+func isRefusedRequest(err error) bool {
+	status := getRequestStatus(err)
+	return status >= http.StatusBadRequest && !isRetriableItemStatus(status)
+}
+
+// getRequestStatus returns the status of a bulk request refused by the cluster, or 0 if the request did not get a response
+func getRequestStatus(err error) int {
+	requestErr := &data.BulkRequestError{}
+	if !errors.As(err, &requestErr) {
+		return 0
+	}
+
+	return requestErr.StatusCode
+}
+
+func rejectAll(items []*bulkItem, err error) []*RejectedDocument {
+	status := getRequestStatus(err)
+	rejected := make([]*RejectedDocument, 0, len(items))
+	for _, item := range items {
+		rejected = append(rejected, &RejectedDocument{
+			ID:     item.id,
+			Status: status,
+			Reason: err.Error(),
+		})
+	}
+
+	return rejected
+}
+
+func describeRejectedDocuments(rejected []*RejectedDocument) string {
+	descriptions := make([]string, 0, numRejectedToDescribe)
+	for idx, doc := range rejected {
+		if idx == numRejectedToDescribe {
+			descriptions = append(descriptions, fmt.Sprintf("and %d more", len(rejected)-numRejectedToDescribe))
+			break
+		}
+
+		descriptions = append(descriptions, fmt.Sprintf("%s (status: %d, type: %s, reason: %s)", doc.ID, doc.Status, doc.Type, doc.Reason))
+	}
+
+	return strings.Join(descriptions, ", ")
+}
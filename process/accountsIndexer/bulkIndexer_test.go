@@ -0,0 +1,212 @@
+package accountsIndexer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/mocks"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func getBulkRequestIDs(buff *bytes.Buffer) []string {
+	lines := strings.Split(strings.TrimSpace(buff.String()), "\n")
+	ids := make([]string, 0, len(lines)/2)
+	for idx := 0; idx < len(lines); idx += 2 {
+		ids = append(ids, gjson.Get(lines[idx], "index._id").String())
+	}
+
+	return ids
+}
+
+func createBulkResponse(ids []string, statuses map[string]int) *data.BulkRequestResponse {
+	response := &data.BulkRequestResponse{}
+	for _, id := range ids {
+		status, ok := statuses[id]
+		if !ok {
+			status = http.StatusCreated
+		}
+
+		item := data.BulkResponseItem{}
+		item.Index.ID = id
+		item.Index.Status = status
+		switch status {
+		case http.StatusTooManyRequests:
+			item.Index.Error.Type = "es_rejected_execution_exception"
+		case http.StatusBadRequest:
+			item.Index.Error.Type = "mapper_parsing_exception"
+			item.Index.Error.Reason = "failed to parse"
+		}
+
+		response.Items = append(response.Items, item)
+		response.Errors = response.Errors || status >= http.StatusMultipleChoices
+	}
+
+	return response
+}
+
+func TestNewAccountsIndexer(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewAccountsIndexer(nil, config.BulkIndexerConfig{})
+	require.Equal(t, ErrNilElasticClient, err)
+
+	_, err = NewAccountsIndexer(&mocks.ElasticClientStub{}, config.BulkIndexerConfig{NumWorkers: -1})
+	require.True(t, errors.Is(err, ErrInvalidBulkIndexerConfig))
+
+	ai, err := NewAccountsIndexer(&mocks.ElasticClientStub{}, config.BulkIndexerConfig{})
+	require.Nil(t, err)
+	require.Equal(t, defaultNumWorkers, ai.bulkSettings.numWorkers)
+	require.Equal(t, defaultMaxRetries, ai.bulkSettings.maxRetries)
+}
+
+func TestAccountsIndexer_IndexAccountsShouldFlushOnThresholds(t *testing.T) {
+	t.Parallel()
+
+	mut := sync.Mutex{}
+	numDocumentsInRequests := make([]int, 0)
+	esClient := &mocks.ElasticClientStub{
+		DoBulkRequestWithItemsCalled: func(buff *bytes.Buffer, index string) (*data.BulkRequestResponse, error) {
+			require.Equal(t, "accounts-000001", index)
+
+			mut.Lock()
+			numDocumentsInRequests = append(numDocumentsInRequests, len(getBulkRequestIDs(buff)))
+			mut.Unlock()
+			return &data.BulkRequestResponse{}, nil
+		},
+	}
+
+	accounts := make(map[string]*data.AccountInfoWithStakeValues)
+	for idx := 0; idx < 5; idx++ {
+		accounts[fmt.Sprintf("erd1%d", idx)] = &data.AccountInfoWithStakeValues{}
+	}
+
+	ai, _ := NewAccountsIndexer(esClient, config.BulkIndexerConfig{NumWorkers: 3, FlushDocuments: 2})
+	err := ai.IndexAccounts(accounts, "accounts-000001")
+	require.Nil(t, err)
+	require.ElementsMatch(t, []int{2, 2, 1}, numDocumentsInRequests)
+
+	numDocumentsInRequests = make([]int, 0)
+	ai, _ = NewAccountsIndexer(esClient, config.BulkIndexerConfig{FlushBytes: 1})
+	err = ai.IndexAccounts(accounts, "accounts-000001")
+	require.Nil(t, err)
+	require.Equal(t, []int{1, 1, 1, 1, 1}, numDocumentsInRequests)
+}
+
+func TestAccountsIndexer_IndexAccountsShouldRetryOnlyTheRetriableItems(t *testing.T) {
+	t.Parallel()
+
+	requests := make([][]string, 0)
+	esClient := &mocks.ElasticClientStub{
+		DoBulkRequestWithItemsCalled: func(buff *bytes.Buffer, _ string) (*data.BulkRequestResponse, error) {
+			ids := getBulkRequestIDs(buff)
+			requests = append(requests, ids)
+			if len(requests) > 1 {
+				return createBulkResponse(ids, nil), nil
+			}
+
+			return createBulkResponse(ids, map[string]int{
+				"erd1b": http.StatusTooManyRequests,
+				"erd1c": http.StatusBadRequest,
+			}), nil
+		},
+	}
+
+	accounts := map[string]*data.AccountInfoWithStakeValues{
+		"erd1a": {},
+		"erd1b": {},
+		"erd1c": {},
+	}
+
+	ai, _ := NewAccountsIndexer(esClient, config.BulkIndexerConfig{NumWorkers: 1, InitialBackoffInMilliseconds: 1})
+	err := ai.IndexAccounts(accounts, "accounts-000001")
+	require.True(t, errors.Is(err, ErrRejectedDocuments))
+	require.Contains(t, err.Error(), "erd1c (status: 400, type: mapper_parsing_exception, reason: failed to parse)")
+	require.NotContains(t, err.Error(), "erd1b")
+
+	require.Len(t, requests, 2)
+	require.ElementsMatch(t, []string{"erd1a", "erd1b", "erd1c"}, requests[0])
+	require.Equal(t, []string{"erd1b"}, requests[1])
+}
+
+func TestAccountsIndexer_IndexAccountsShouldRejectAfterMaxRetries(t *testing.T) {
+	t.Parallel()
+
+	numRequests := 0
+	esClient := &mocks.ElasticClientStub{
+		DoBulkRequestWithItemsCalled: func(buff *bytes.Buffer, _ string) (*data.BulkRequestResponse, error) {
+			numRequests++
+			if numRequests%2 == 0 {
+				return nil, errors.New("connection reset")
+			}
+
+			return createBulkResponse(getBulkRequestIDs(buff), map[string]int{"erd1a": http.StatusTooManyRequests}), nil
+		},
+	}
+
+	ai, _ := NewAccountsIndexer(esClient, config.BulkIndexerConfig{NumWorkers: 1, MaxRetries: 2, InitialBackoffInMilliseconds: 1})
+	err := ai.IndexAccounts(map[string]*data.AccountInfoWithStakeValues{"erd1a": {}}, "accounts-000001")
+	require.True(t, errors.Is(err, ErrRejectedDocuments))
+	require.Contains(t, err.Error(), "1 documents in index accounts-000001: erd1a (status: 429, type: es_rejected_execution_exception")
+	require.Equal(t, 3, numRequests)
+}
+
+func TestAccountsIndexer_IndexAccountsShouldNotRetryARefusedRequest(t *testing.T) {
+	t.Parallel()
+
+	numRequests := 0
+	esClient := &mocks.ElasticClientStub{
+		DoBulkRequestWithItemsCalled: func(_ *bytes.Buffer, _ string) (*data.BulkRequestResponse, error) {
+			numRequests++
+			return nil, &data.BulkRequestError{StatusCode: http.StatusUnauthorized, Message: "error DoBulkRequest: [401 Unauthorized]"}
+		},
+	}
+
+	ai, _ := NewAccountsIndexer(esClient, config.BulkIndexerConfig{NumWorkers: 1, MaxRetries: 3, InitialBackoffInMilliseconds: 1})
+	err := ai.IndexAccounts(map[string]*data.AccountInfoWithStakeValues{"erd1a": {}, "erd1b": {}}, "accounts-000001")
+	require.True(t, errors.Is(err, ErrRejectedDocuments))
+	require.Contains(t, err.Error(), "2 documents in index accounts-000001")
+	require.Contains(t, err.Error(), "(status: 401, type: , reason: error DoBulkRequest: [401 Unauthorized])")
+	require.Equal(t, 1, numRequests)
+}
+
+func TestAccountsIndexer_IndexAccountsShouldRetryAnOverloadedRequest(t *testing.T) {
+	t.Parallel()
+
+	numRequests := 0
+	esClient := &mocks.ElasticClientStub{
+		DoBulkRequestWithItemsCalled: func(buff *bytes.Buffer, _ string) (*data.BulkRequestResponse, error) {
+			numRequests++
+			if numRequests == 1 {
+				return nil, &data.BulkRequestError{StatusCode: http.StatusServiceUnavailable, Message: "error DoBulkRequest: [503 Service Unavailable]"}
+			}
+
+			return createBulkResponse(getBulkRequestIDs(buff), nil), nil
+		},
+	}
+
+	ai, _ := NewAccountsIndexer(esClient, config.BulkIndexerConfig{NumWorkers: 1, MaxRetries: 3, InitialBackoffInMilliseconds: 1})
+	err := ai.IndexAccounts(map[string]*data.AccountInfoWithStakeValues{"erd1a": {}}, "accounts-000001")
+	require.Nil(t, err)
+	require.Equal(t, 2, numRequests)
+}
+
+func TestDescribeRejectedDocuments(t *testing.T) {
+	t.Parallel()
+
+	rejected := make([]*RejectedDocument, 0)
+	for idx := 0; idx < numRejectedToDescribe+2; idx++ {
+		rejected = append(rejected, &RejectedDocument{ID: fmt.Sprintf("erd1%d", idx), Status: http.StatusBadRequest})
+	}
+
+	description := describeRejectedDocuments(rejected)
+	require.True(t, strings.HasPrefix(description, "erd10 (status: 400, type: , reason: ), erd11"))
+	require.True(t, strings.HasSuffix(description, "and 2 more"))
+}
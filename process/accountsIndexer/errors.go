@@ -0,0 +1,15 @@
+package accountsIndexer
+
+import "errors"
+
+// ErrNilElasticClient signals that a nil elastic client was provided
+var ErrNilElasticClient = errors.New("nil elastic client")
+
+// ErrInvalidBulkIndexerConfig signals that an invalid bulk indexer configuration was provided
+var ErrInvalidBulkIndexerConfig = errors.New("invalid bulk indexer config")
+
+// ErrRejectedDocuments signals that some documents were permanently rejected by the elastic cluster
+var ErrRejectedDocuments = errors.New("documents rejected by the elastic cluster")
+
+// ErrCannotGetAccounts signals that the accounts could not be read from the elastic cluster
+var ErrCannotGetAccounts = errors.New("cannot get accounts")
@@ -1,11 +1,16 @@
 package accountsIndexer
 
-import "bytes"
+import (
+	"bytes"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+)
 
 // ElasticClientHandler defines what an elastic client should be able to do
 type ElasticClientHandler interface {
 	PutMapping(targetIndex string, body *bytes.Buffer) error
 	DoBulkRequest(buff *bytes.Buffer, index string) error
+	DoBulkRequestWithItems(buff *bytes.Buffer, index string) (*data.BulkRequestResponse, error)
 	DoMultiGet(ids []string, index string) ([]byte, error)
 	DoScrollRequestAllDocuments(index string, body []byte, handlerFunc func(responseBytes []byte) error) error
 	IsInterfaceNil() bool
@@ -1,165 +1,279 @@
 package process
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"math/big"
+	"sync"
+	"time"
 
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
 	"github.com/multiversx/mx-chain-tools-accounts-manager-go/core"
 	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/metrics"
 	"github.com/tidwall/gjson"
 )
 
 const (
 	pathNodeStatusMeta = "/network/status/4294967295"
+
+	// defaultSourceStopTimeout is how long a cancelled stake source is waited for, so that its requests do not keep
+	// running after the run failed
+	defaultSourceStopTimeout = 30 * time.Second
 )
 
 type accountsProcessor struct {
-	AccountsGetterHandler
-	restClient RestClientHandler
+	restClient            RestClientHandler
+	stakeSources          []StakeSource
+	optionalSources       map[string]struct{}
+	snapshotBlockResolver SnapshotBlockResolver
+	sourceTimeout         time.Duration
+	sourceStopTimeout     time.Duration
 }
 
-// NewAccountsProcessor will create a new instance of accountsProcessor
-func NewAccountsProcessor(restClient RestClientHandler, acctsGetter AccountsGetterHandler) (*accountsProcessor, error) {
-	return &accountsProcessor{
-		restClient:            restClient,
-		AccountsGetterHandler: acctsGetter,
-	}, nil
+type sourceResult struct {
+	accounts  map[string]*data.AccountInfoWithStakeValues
+	blockInfo *data.BlockInfo
+	duration  time.Duration
+	err       error
 }
 
-// GetAllAccountsWithStake will return all accounts with stake
-func (ap *accountsProcessor) GetAllAccountsWithStake(currentEpoch uint32) (*data.AccountsData, error) {
-	legacyDelegators, err := ap.GetLegacyDelegatorsAccounts()
+// NewAccountsProcessor will create a new instance of accountsProcessor. The accounts of the stake sources are merged
+// in the order of the provided stake sources
+func NewAccountsProcessor(
+	restClient RestClientHandler,
+	stakeSources []StakeSource,
+	snapshotBlockResolver SnapshotBlockResolver,
+	stakeSourcesConfig config.StakeSourcesConfig,
+) (*accountsProcessor, error) {
+	if len(stakeSources) == 0 {
+		return nil, ErrNoStakeSource
+	}
+	for _, source := range stakeSources {
+		if check.IfNil(source) {
+			return nil, ErrNilStakeSource
+		}
+	}
+	if check.IfNil(snapshotBlockResolver) {
+		return nil, ErrNilSnapshotBlockResolver
+	}
+	if stakeSourcesConfig.TimeoutInSeconds < 0 {
+		return nil, ErrInvalidSourceTimeout
+	}
+	optionalSources, err := createOptionalSources(stakeSources, stakeSourcesConfig.Optional)
 	if err != nil {
 		return nil, err
 	}
 
-	validators, err := ap.GetValidatorsAccounts()
-	if err != nil {
-		return nil, err
+	return &accountsProcessor{
+		restClient:            restClient,
+		stakeSources:          stakeSources,
+		optionalSources:       optionalSources,
+		snapshotBlockResolver: snapshotBlockResolver,
+		sourceTimeout:         time.Duration(stakeSourcesConfig.TimeoutInSeconds) * time.Second,
+		sourceStopTimeout:     defaultSourceStopTimeout,
+	}, nil
+}
+
+func createOptionalSources(stakeSources []StakeSource, names []string) (map[string]struct{}, error) {
+	enabled := make(map[string]struct{}, len(stakeSources))
+	for _, source := range stakeSources {
+		enabled[source.Name()] = struct{}{}
 	}
 
-	delegators, err := ap.GetDelegatorsAccounts()
-	if err != nil {
-		return nil, err
+	optionalSources := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		if _, found := enabled[name]; !found {
+			return nil, fmt.Errorf("%w: optional source %s is not enabled", ErrUnknownStakeSource, name)
+		}
+		optionalSources[name] = struct{}{}
 	}
 
-	lkMexAccountsWithStake, err := ap.GetLKMEXStakeAccounts()
+	return optionalSources, nil
+}
+
+// GetAllAccountsWithStake will return all accounts with stake
+func (ap *accountsProcessor) GetAllAccountsWithStake(currentEpoch uint32) (*data.AccountsData, error) {
+	defer logExecutionTime(time.Now(), "Fetched accounts from all stake sources")
+
+	snapshotBlock, err := ap.snapshotBlockResolver.ResolveSnapshotBlock(context.Background(), currentEpoch)
 	if err != nil {
 		return nil, err
 	}
 
-	accountsWithEnergy, blockInfoEnergy, err := ap.GetAccountsWithEnergy(currentEpoch)
+	results, err := ap.fetchSources(currentEpoch, snapshotBlock)
 	if err != nil {
 		return nil, err
 	}
 
-	allAccounts, allAddresses := ap.mergeAccounts(legacyDelegators, validators, delegators, lkMexAccountsWithStake, accountsWithEnergy)
+	allAccounts, allAddresses := ap.mergeAccounts(results)
 
-	calculateTotalStakeForAccountsAndTotalUnDelegated(allAccounts)
+	ap.calculateTotalStakeForAccountsAndTotalUnDelegated(allAccounts)
+
+	sourcesInfo := make([]data.SourceInfo, 0, len(ap.stakeSources))
+	var energyBlockInfo *data.BlockInfo
+	for idx, source := range ap.stakeSources {
+		sourceInfo := data.SourceInfo{
+			Name:              source.Name(),
+			NumAccounts:       len(results[idx].accounts),
+			DurationInSeconds: results[idx].duration.Seconds(),
+			BlockInfo:         results[idx].blockInfo,
+		}
+		if results[idx].err != nil {
+			sourceInfo.Error = results[idx].err.Error()
+		}
+		sourcesInfo = append(sourcesInfo, sourceInfo)
+		if source.Name() == energySourceName {
+			energyBlockInfo = results[idx].blockInfo
+		}
+	}
 
 	return &data.AccountsData{
 		AccountsWithStake: allAccounts,
 		Addresses:         allAddresses,
-		EnergyBlockInfo:   blockInfoEnergy,
+		EnergyBlockInfo:   energyBlockInfo,
+		SnapshotBlock:     snapshotBlock,
 		Epoch:             currentEpoch,
+		Sources:           sourcesInfo,
 	}, nil
 }
 
-func calculateTotalStakeForAccountsAndTotalUnDelegated(accounts map[string]*data.AccountInfoWithStakeValues) {
-	for _, account := range accounts {
-		totalStake, totalStakeNum := computeTotalBalance(
-			account.DelegationLegacyWaiting,
-			account.DelegationLegacyActive,
-			account.ValidatorsActive,
-			account.ValidatorTopUp,
-			account.Delegation,
-		)
-
-		account.TotalStake = totalStake
-		account.TotalStakeNum = totalStakeNum
+// fetchSources fetches the accounts from all the stake sources concurrently. The results are returned in the same
+// order as the stake sources, so the merge does not depend on which source finished first. The first failing required
+// source cancels all the others, while a failing optional source is only reported and its accounts are left out
+func (ap *accountsProcessor) fetchSources(epoch uint32, snapshotBlock *data.SnapshotBlock) ([]sourceResult, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	results := make([]sourceResult, len(ap.stakeSources))
+	wg := &sync.WaitGroup{}
+	for idx := range ap.stakeSources {
+		wg.Add(1)
+
+		go func(idx int) {
+			defer wg.Done()
+
+			results[idx] = ap.fetchSource(ctx, ap.stakeSources[idx], epoch, snapshotBlock)
+			if results[idx].err != nil && !ap.isOptional(ap.stakeSources[idx]) {
+				cancel()
+			}
+		}(idx)
+	}
+	wg.Wait()
 
-		totalUnDelegated, totalUnDelegatedNum := computeTotalBalance(
-			account.UnDelegateLegacy,
-			account.UnDelegateValidator,
-			account.UnDelegateDelegation,
-		)
+	failedIdx := -1
+	for idx, result := range results {
+		if result.err == nil || ap.isOptional(ap.stakeSources[idx]) {
+			continue
+		}
+		// prefer the source that caused the cancellation over the ones that were cancelled
+		if failedIdx < 0 || errors.Is(results[failedIdx].err, context.Canceled) {
+			failedIdx = idx
+		}
+	}
+	if failedIdx >= 0 {
+		return nil, fmt.Errorf("%w while fetching accounts from source %s", results[failedIdx].err, ap.stakeSources[failedIdx].Name())
+	}
 
-		account.TotalUnDelegate = totalUnDelegated
-		account.TotalUnDelegateNum = totalUnDelegatedNum
+	for idx, result := range results {
+		if result.err != nil {
+			log.Warn("the optional stake source failed, its accounts are left out of the snapshot",
+				"source", ap.stakeSources[idx].Name(), "error", result.err)
+			results[idx].accounts = nil
+		}
 	}
-}
 
-func (ap *accountsProcessor) mergeAccounts(
-	legacyDelegators, validators, delegators, lkMexAccountsWithStake, accountsWithEnergy map[string]*data.AccountInfoWithStakeValues,
-) (map[string]*data.AccountInfoWithStakeValues, []string) {
-	allAddresses := make([]string, 0)
-	mergedAccounts := make(map[string]*data.AccountInfoWithStakeValues)
+	return results, nil
+}
 
-	for address, legacyDelegator := range legacyDelegators {
-		mergedAccounts[address] = legacyDelegator
+func (ap *accountsProcessor) isOptional(source StakeSource) bool {
+	_, isOptional := ap.optionalSources[source.Name()]
+	return isOptional
+}
 
-		allAddresses = append(allAddresses, address)
+func (ap *accountsProcessor) fetchSource(
+	parentCtx context.Context,
+	source StakeSource,
+	epoch uint32,
+	snapshotBlock *data.SnapshotBlock,
+) sourceResult {
+	ctx, cancel := parentCtx, context.CancelFunc(func() {})
+	if ap.sourceTimeout > 0 {
+		ctx, cancel = context.WithTimeout(parentCtx, ap.sourceTimeout)
 	}
-
-	for address, stakedValidators := range validators {
-		_, ok := mergedAccounts[address]
-		if !ok {
-			mergedAccounts[address] = stakedValidators
-
-			allAddresses = append(allAddresses, address)
-			continue
+	defer cancel()
+
+	start := time.Now()
+	// the fetch is run in its own goroutine so that a getter which is slow to notice the cancellation does not delay
+	// the other sources past the deadline
+	resultChan := make(chan sourceResult, 1)
+	go func() {
+		accounts, blockInfo, err := source.FetchAccounts(ctx, epoch, snapshotBlock)
+		resultChan <- sourceResult{accounts: accounts, blockInfo: blockInfo, err: err}
+	}()
+
+	select {
+	case result := <-resultChan:
+		result.duration = time.Since(start)
+		metrics.ObserveSourceFetch(source.Name(), result.duration, len(result.accounts), result.err)
+		if result.err != nil {
+			log.Warn("cannot fetch accounts", "source", source.Name(), "error", result.err, "duration", time.Since(start))
+			return result
 		}
 
-		mergedAccounts[address].ValidatorsActive = stakedValidators.ValidatorsActive
-		mergedAccounts[address].ValidatorsActiveNum = stakedValidators.ValidatorsActiveNum
-		mergedAccounts[address].ValidatorTopUp = stakedValidators.ValidatorTopUp
-		mergedAccounts[address].ValidatorTopUpNum = stakedValidators.ValidatorTopUpNum
-
-		mergedAccounts[address].UnDelegateValidator = stakedValidators.UnDelegateValidator
-		mergedAccounts[address].UnDelegateValidatorNum = stakedValidators.UnDelegateValidatorNum
+		log.Info("fetched accounts", "source", source.Name(), "num", len(result.accounts), "duration", time.Since(start))
+		return result
+	case <-ctx.Done():
+		metrics.ObserveSourceFetch(source.Name(), time.Since(start), 0, ctx.Err())
+		log.Warn("stopped fetching accounts", "source", source.Name(), "error", ctx.Err(), "duration", time.Since(start))
+		ap.waitForSourceToStop(source, resultChan)
+		return sourceResult{err: ctx.Err(), duration: time.Since(start)}
 	}
+}
 
-	for address, stakedDelegators := range delegators {
-		_, ok := mergedAccounts[address]
-		if !ok {
-			mergedAccounts[address] = stakedDelegators
+// waitForSourceToStop will wait a bounded time for a cancelled stake source to return, so that its requests do not
+// pile up with the ones of the next attempt
+func (ap *accountsProcessor) waitForSourceToStop(source StakeSource, resultChan chan sourceResult) {
+	select {
+	case <-resultChan:
+	case <-time.After(ap.sourceStopTimeout):
+		log.Error("the stake source did not stop after it was cancelled, its requests may still be running",
+			"source", source.Name(), "waited", ap.sourceStopTimeout)
+	}
+}
 
-			allAddresses = append(allAddresses, address)
-			continue
+func (ap *accountsProcessor) calculateTotalStakeForAccountsAndTotalUnDelegated(accounts map[string]*data.AccountInfoWithStakeValues) {
+	for _, account := range accounts {
+		stakeValues := make([]string, 0)
+		unDelegatedValues := make([]string, 0)
+		for _, source := range ap.stakeSources {
+			stakeValues = append(stakeValues, source.TotalStakeValues(account)...)
+			unDelegatedValues = append(unDelegatedValues, source.TotalUnDelegatedValues(account)...)
 		}
 
-		mergedAccounts[address].Delegation = stakedDelegators.Delegation
-		mergedAccounts[address].DelegationNum = stakedDelegators.DelegationNum
-
-		mergedAccounts[address].UnDelegateDelegation = stakedDelegators.UnDelegateDelegation
-		mergedAccounts[address].UnDelegateDelegationNum = stakedDelegators.UnDelegateDelegationNum
+		account.TotalStake, account.TotalStakeNum = computeTotalBalance(stakeValues...)
+		account.TotalUnDelegate, account.TotalUnDelegateNum = computeTotalBalance(unDelegatedValues...)
 	}
+}
 
-	for address, lkMexAccount := range lkMexAccountsWithStake {
-		_, ok := mergedAccounts[address]
-		if !ok {
-			mergedAccounts[address] = lkMexAccount
-
-			allAddresses = append(allAddresses, address)
-			continue
-		}
+func (ap *accountsProcessor) mergeAccounts(results []sourceResult) (map[string]*data.AccountInfoWithStakeValues, []string) {
+	allAddresses := make([]string, 0)
+	mergedAccounts := make(map[string]*data.AccountInfoWithStakeValues)
 
-		mergedAccounts[address].LKMEXStake = lkMexAccount.LKMEXStake
-		mergedAccounts[address].LKMEXStakeNum = lkMexAccount.LKMEXStakeNum
-	}
+	for idx, source := range ap.stakeSources {
+		for address, account := range results[idx].accounts {
+			mergedAccount, ok := mergedAccounts[address]
+			if !ok {
+				mergedAccounts[address] = account
 
-	for address, energyAccount := range accountsWithEnergy {
-		_, ok := mergedAccounts[address]
-		if !ok {
-			mergedAccounts[address] = energyAccount
+				allAddresses = append(allAddresses, address)
+				continue
+			}
 
-			allAddresses = append(allAddresses, address)
-			continue
+			source.MergeAccount(mergedAccount, account)
 		}
-
-		mergedAccounts[address].Energy = energyAccount.Energy
-		mergedAccounts[address].EnergyNum = energyAccount.EnergyNum
-		mergedAccounts[address].EnergyDetails = energyAccount.EnergyDetails
 	}
 
 	return mergedAccounts, allAddresses
@@ -174,17 +288,53 @@ func (ap *accountsProcessor) ComputeClonedAccountsIndex(epoch uint32) (string, e
 
 // GetCurrentEpoch will fetch the current epoch from the network
 func (ap *accountsProcessor) GetCurrentEpoch() (uint32, error) {
-	genericAPIResponse := &data.GenericAPIResponse{}
-	err := ap.restClient.CallGetRestEndPoint(pathNodeStatusMeta, genericAPIResponse, core.GetEmptyApiCredentials())
+	status, err := ap.getNetworkData(pathNodeStatusMeta)
 	if err != nil {
 		return 0, err
 	}
+
+	epoch := gjson.Get(status, "status.erd_epoch_number")
+	return uint32(epoch.Num), nil
+}
+
+// GetCurrentEpochStatus will fetch the current epoch from the network, together with the time at which it started. The
+// start time is computed from the genesis time, the round duration and the round of the epoch start
+func (ap *accountsProcessor) GetCurrentEpochStatus() (*data.EpochStatus, error) {
+	status, err := ap.getNetworkData(pathNodeStatusMeta)
+	if err != nil {
+		return nil, err
+	}
+	networkConfig, err := ap.getNetworkData(pathNetworkConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	genesisTime := gjson.Get(networkConfig, "config.erd_start_time").Int()
+	roundDurationInMillis := gjson.Get(networkConfig, "config.erd_round_duration").Int()
+	roundAtEpochStart := gjson.Get(status, "status.erd_round_at_epoch_start")
+	if genesisTime <= 0 || roundDurationInMillis <= 0 || !roundAtEpochStart.Exists() {
+		return nil, fmt.Errorf("%w: missing genesis time, round duration or epoch start round", ErrInvalidEpochStart)
+	}
+
+	sinceGenesis := time.Duration(roundAtEpochStart.Int()*roundDurationInMillis) * time.Millisecond
+
+	return &data.EpochStatus{
+		Epoch:     uint32(gjson.Get(status, "status.erd_epoch_number").Uint()),
+		StartTime: time.Unix(genesisTime, 0).Add(sinceGenesis),
+	}, nil
+}
+
+func (ap *accountsProcessor) getNetworkData(path string) (string, error) {
+	genericAPIResponse := &data.GenericAPIResponse{}
+	err := ap.restClient.CallGetRestEndPoint(context.Background(), path, genericAPIResponse, core.GetEmptyApiCredentials())
+	if err != nil {
+		return "", err
+	}
 	if genericAPIResponse.Error != "" {
-		return 0, fmt.Errorf("cannot compute accounts index %s", genericAPIResponse.Error)
+		return "", fmt.Errorf("cannot get %s: %s", path, genericAPIResponse.Error)
 	}
 
-	epoch := gjson.Get(string(genericAPIResponse.Data), "status.erd_epoch_number")
-	return uint32(epoch.Num), nil
+	return string(genericAPIResponse.Data), nil
 }
 
 func computeTotalBalance(balances ...string) (string, float64) {
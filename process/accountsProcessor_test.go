@@ -1,10 +1,15 @@
 package process
 
 import (
+	"context"
 	"crypto/rand"
+	"errors"
 	"math/big"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
 	"github.com/multiversx/mx-chain-tools-accounts-manager-go/core"
 	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
 	"github.com/multiversx/mx-chain-tools-accounts-manager-go/mocks"
@@ -27,17 +32,17 @@ func TestAccountsProcessor_GetAllAccountsWithStake(t *testing.T) {
 	mapLegacyDelegation := makeMapFromArrays(keys[5:35], accountsDelegationLegacy)
 	mapValidators := makeMapFromArrays(keys[15:45], accountsValidators)
 
-	ap, err := NewAccountsProcessor(&mocks.RestClientStub{}, &mocks.AccountsGetterStub{
-		GetDelegatorsAccountsCalled: func() (map[string]*data.AccountInfoWithStakeValues, error) {
+	ap, err := NewAccountsProcessor(&mocks.RestClientStub{}, createDefaultStakeSources(t, &mocks.AccountsGetterStub{
+		GetDelegatorsAccountsCalled: func(_ context.Context, _ *data.SnapshotBlock) (map[string]*data.AccountInfoWithStakeValues, error) {
 			return mapDelegation, nil
 		},
-		GetLegacyDelegatorsAccountsCalled: func() (map[string]*data.AccountInfoWithStakeValues, error) {
+		GetLegacyDelegatorsAccountsCalled: func(_ context.Context, _ *data.SnapshotBlock) (map[string]*data.AccountInfoWithStakeValues, error) {
 			return mapLegacyDelegation, nil
 		},
-		GetValidatorsAccountsCalled: func() (map[string]*data.AccountInfoWithStakeValues, error) {
+		GetValidatorsAccountsCalled: func(_ context.Context, _ *data.SnapshotBlock) (map[string]*data.AccountInfoWithStakeValues, error) {
 			return mapValidators, nil
 		},
-	})
+	}), &mocks.SnapshotBlockResolverStub{}, config.StakeSourcesConfig{})
 	require.Nil(t, err)
 
 	accountsData, err := ap.GetAllAccountsWithStake(0)
@@ -84,6 +89,183 @@ func TestAccountsProcessor_GetAllAccountsWithStake(t *testing.T) {
 	}
 }
 
+func TestAccountsProcessor_GetAllAccountsWithStakeSourceErrorCancelsTheOthers(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("local error")
+	ap, err := NewAccountsProcessor(&mocks.RestClientStub{}, createDefaultStakeSources(t, &mocks.AccountsGetterStub{
+		GetValidatorsAccountsCalled: func(_ context.Context, _ *data.SnapshotBlock) (map[string]*data.AccountInfoWithStakeValues, error) {
+			return nil, expectedErr
+		},
+		GetDelegatorsAccountsCalled: func(ctx context.Context, _ *data.SnapshotBlock) (map[string]*data.AccountInfoWithStakeValues, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	}), &mocks.SnapshotBlockResolverStub{}, config.StakeSourcesConfig{})
+	require.Nil(t, err)
+
+	accountsData, err := ap.GetAllAccountsWithStake(0)
+	require.Nil(t, accountsData)
+	require.True(t, errors.Is(err, expectedErr))
+	require.Contains(t, err.Error(), validatorsSourceName)
+}
+
+func TestAccountsProcessor_GetAllAccountsWithStakeSourceTimeout(t *testing.T) {
+	t.Parallel()
+
+	ap, err := NewAccountsProcessor(&mocks.RestClientStub{}, createDefaultStakeSources(t, &mocks.AccountsGetterStub{
+		GetLKMEXStakeAccountsCalled: func(_ context.Context, _ *data.SnapshotBlock) (map[string]*data.AccountInfoWithStakeValues, error) {
+			// simulates a getter that does not honor the context
+			time.Sleep(5 * time.Second)
+			return nil, nil
+		},
+	}), &mocks.SnapshotBlockResolverStub{}, config.StakeSourcesConfig{TimeoutInSeconds: 1})
+	require.Nil(t, err)
+	ap.sourceStopTimeout = 100 * time.Millisecond
+
+	start := time.Now()
+	accountsData, err := ap.GetAllAccountsWithStake(0)
+	require.Nil(t, accountsData)
+	require.True(t, errors.Is(err, context.DeadlineExceeded))
+	require.Contains(t, err.Error(), lkMexSourceName)
+	require.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestAccountsProcessor_GetAllAccountsWithStakeShouldWaitForTheCancelledSources(t *testing.T) {
+	t.Parallel()
+
+	stopped := atomic.Value{}
+	stopped.Store(false)
+	ap, err := NewAccountsProcessor(&mocks.RestClientStub{}, createDefaultStakeSources(t, &mocks.AccountsGetterStub{
+		GetValidatorsAccountsCalled: func(_ context.Context, _ *data.SnapshotBlock) (map[string]*data.AccountInfoWithStakeValues, error) {
+			return nil, errors.New("local error")
+		},
+		GetDelegatorsAccountsCalled: func(ctx context.Context, _ *data.SnapshotBlock) (map[string]*data.AccountInfoWithStakeValues, error) {
+			<-ctx.Done()
+			// simulates a getter that needs some time to notice the cancellation
+			time.Sleep(100 * time.Millisecond)
+			stopped.Store(true)
+			return nil, ctx.Err()
+		},
+	}), &mocks.SnapshotBlockResolverStub{}, config.StakeSourcesConfig{})
+	require.Nil(t, err)
+
+	_, err = ap.GetAllAccountsWithStake(0)
+	require.NotNil(t, err)
+	require.True(t, stopped.Load().(bool))
+}
+
+func TestAccountsProcessor_GetAllAccountsWithStakeOptionalSourceFailure(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("local error")
+	ap, err := NewAccountsProcessor(&mocks.RestClientStub{}, createDefaultStakeSources(t, &mocks.AccountsGetterStub{
+		GetLKMEXStakeAccountsCalled: func(_ context.Context, _ *data.SnapshotBlock) (map[string]*data.AccountInfoWithStakeValues, error) {
+			return map[string]*data.AccountInfoWithStakeValues{"erd1a": {}}, expectedErr
+		},
+		GetValidatorsAccountsCalled: func(_ context.Context, _ *data.SnapshotBlock) (map[string]*data.AccountInfoWithStakeValues, error) {
+			return map[string]*data.AccountInfoWithStakeValues{"erd1b": {}}, nil
+		},
+	}), &mocks.SnapshotBlockResolverStub{}, config.StakeSourcesConfig{Optional: []string{lkMexSourceName}})
+	require.Nil(t, err)
+
+	accountsData, err := ap.GetAllAccountsWithStake(0)
+	require.Nil(t, err)
+	require.Len(t, accountsData.AccountsWithStake, 1)
+	require.NotNil(t, accountsData.AccountsWithStake["erd1b"])
+	for _, sourceInfo := range accountsData.Sources {
+		if sourceInfo.Name == lkMexSourceName {
+			require.Equal(t, expectedErr.Error(), sourceInfo.Error)
+			require.Equal(t, 0, sourceInfo.NumAccounts)
+			continue
+		}
+		require.Empty(t, sourceInfo.Error)
+	}
+
+	_, err = NewAccountsProcessor(&mocks.RestClientStub{}, createDefaultStakeSources(t, &mocks.AccountsGetterStub{}),
+		&mocks.SnapshotBlockResolverStub{}, config.StakeSourcesConfig{Optional: []string{"unknown"}})
+	require.True(t, errors.Is(err, ErrUnknownStakeSource))
+}
+
+func TestNewAccountsProcessor_InvalidSourceTimeout(t *testing.T) {
+	t.Parallel()
+
+	ap, err := NewAccountsProcessor(&mocks.RestClientStub{}, createDefaultStakeSources(t, &mocks.AccountsGetterStub{}), &mocks.SnapshotBlockResolverStub{}, config.StakeSourcesConfig{TimeoutInSeconds: -1})
+	require.Nil(t, ap)
+	require.Equal(t, ErrInvalidSourceTimeout, err)
+}
+
+func TestNewAccountsProcessor_NoStakeSource(t *testing.T) {
+	t.Parallel()
+
+	ap, err := NewAccountsProcessor(&mocks.RestClientStub{}, nil, &mocks.SnapshotBlockResolverStub{}, config.StakeSourcesConfig{})
+	require.Nil(t, ap)
+	require.Equal(t, ErrNoStakeSource, err)
+}
+
+func TestAccountsProcessor_GetAllAccountsWithStakeMergesInSourcesOrder(t *testing.T) {
+	t.Parallel()
+
+	address := "erd1"
+	createSource := func(name string, value string) StakeSource {
+		source, err := NewStakeSource(ArgsStakeSource{
+			Name: name,
+			Fetch: func(_ context.Context, _ uint32, _ *data.SnapshotBlock) (map[string]*data.AccountInfoWithStakeValues, *data.BlockInfo, error) {
+				return map[string]*data.AccountInfoWithStakeValues{
+					address: {StakeInfo: data.StakeInfo{Delegation: value, LKMEXStake: value}},
+				}, &data.BlockInfo{Hash: name}, nil
+			},
+			Merge: func(destination *data.AccountInfoWithStakeValues, source *data.AccountInfoWithStakeValues) {
+				destination.LKMEXStake = source.LKMEXStake
+			},
+			StakeValues: func(account *data.AccountInfoWithStakeValues) []string {
+				return []string{account.Delegation}
+			},
+			UnDelegatedValues: func(account *data.AccountInfoWithStakeValues) []string {
+				return []string{account.LKMEXStake}
+			},
+		})
+		require.Nil(t, err)
+
+		return source
+	}
+
+	sources := []StakeSource{createSource("first", "10"), createSource(energySourceName, "20")}
+	ap, err := NewAccountsProcessor(&mocks.RestClientStub{}, sources, &mocks.SnapshotBlockResolverStub{}, config.StakeSourcesConfig{})
+	require.Nil(t, err)
+
+	accountsData, err := ap.GetAllAccountsWithStake(1)
+	require.Nil(t, err)
+	require.Equal(t, []string{address}, accountsData.Addresses)
+	// the energy block is the one of the energy source, even if another source returned a block first
+	require.Equal(t, energySourceName, accountsData.EnergyBlockInfo.Hash)
+	require.Len(t, accountsData.Sources, 2)
+	for idx, name := range []string{"first", energySourceName} {
+		require.Equal(t, name, accountsData.Sources[idx].Name)
+		require.Equal(t, 1, accountsData.Sources[idx].NumAccounts)
+		require.Equal(t, name, accountsData.Sources[idx].BlockInfo.Hash)
+		require.True(t, accountsData.Sources[idx].DurationInSeconds > 0)
+	}
+
+	account := accountsData.AccountsWithStake[address]
+	require.Equal(t, "10", account.Delegation)
+	require.Equal(t, "20", account.LKMEXStake)
+	// each stake source counts the delegation field, which was kept from the first source
+	require.Equal(t, "20", account.TotalStake)
+	require.Equal(t, "40", account.TotalUnDelegate)
+}
+
+func createDefaultStakeSources(t *testing.T, getter AccountsGetterHandler) []StakeSource {
+	registry := NewStakeSourcesRegistry()
+	err := RegisterDefaultStakeSources(registry, getter)
+	require.Nil(t, err)
+
+	sources, err := registry.GetSources(nil)
+	require.Nil(t, err)
+
+	return sources
+}
+
 const (
 	delegation = iota
 	validator
@@ -131,3 +313,29 @@ func generateRandomBigIntString() string {
 
 	return big.NewInt(0).SetBytes(blk).String()
 }
+
+func TestAccountsProcessor_GetCurrentEpochStatus(t *testing.T) {
+	t.Parallel()
+
+	responses := map[string]string{
+		pathNodeStatusMeta: `{"status":{"erd_epoch_number":10,"erd_round_at_epoch_start":100}}`,
+		pathNetworkConfig:  `{"config":{"erd_start_time":1000,"erd_round_duration":6000}}`,
+	}
+	restClient := &mocks.RestClientStub{
+		CallGetRestEndPointCalled: func(_ context.Context, path string, value interface{}, _ data.RestApiAuthenticationData) error {
+			value.(*data.GenericAPIResponse).Data = []byte(responses[path])
+			return nil
+		},
+	}
+	ap, _ := NewAccountsProcessor(restClient, createDefaultStakeSources(t, &mocks.AccountsGetterStub{}), &mocks.SnapshotBlockResolverStub{}, config.StakeSourcesConfig{})
+
+	epochStatus, err := ap.GetCurrentEpochStatus()
+	require.Nil(t, err)
+	require.Equal(t, uint32(10), epochStatus.Epoch)
+	require.Equal(t, time.Unix(1000, 0).Add(600*time.Second), epochStatus.StartTime)
+
+	responses[pathNetworkConfig] = `{"config":{}}`
+	epochStatus, err = ap.GetCurrentEpochStatus()
+	require.Nil(t, epochStatus)
+	require.ErrorIs(t, err, ErrInvalidEpochStart)
+}
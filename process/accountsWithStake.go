@@ -1,9 +1,12 @@
 package process
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"math/big"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -58,12 +61,17 @@ func NewAccountsGetter(
 }
 
 // GetLegacyDelegatorsAccounts will fetch all accounts with stake from API
-func (ag *accountsGetter) GetLegacyDelegatorsAccounts() (map[string]*data.AccountInfoWithStakeValues, error) {
+func (ag *accountsGetter) GetLegacyDelegatorsAccounts(ctx context.Context, snapshotBlock *data.SnapshotBlock) (map[string]*data.AccountInfoWithStakeValues, error) {
 	defer logExecutionTime(time.Now(), "Fetched accounts from legacy delegation contract")
 
+	blockQuery, err := ag.blockQueryForAddress(ag.delegationContractAddress, snapshotBlock)
+	if err != nil {
+		return nil, err
+	}
+
 	responseKeys := &data.GenericAPIResponse{}
-	path := fmt.Sprintf(pathAddressKeys, ag.delegationContractAddress)
-	err := ag.restClient.CallGetRestEndPoint(path, responseKeys, core.GetEmptyApiCredentials())
+	path := fmt.Sprintf(pathAddressKeys, ag.delegationContractAddress) + blockQuery
+	err = ag.restClient.CallGetRestEndPoint(ctx, path, responseKeys, core.GetEmptyApiCredentials())
 	if err != nil {
 		return nil, err
 	}
@@ -71,6 +79,11 @@ func (ag *accountsGetter) GetLegacyDelegatorsAccounts() (map[string]*data.Accoun
 		return nil, fmt.Errorf("%s", responseKeys.Error)
 	}
 
+	err = checkResponseBlock(responseKeys.Data, blockQuery)
+	if err != nil {
+		return nil, err
+	}
+
 	pairs := gjson.Get(string(responseKeys.Data), "pairs")
 
 	pairsMap := make(map[string]string)
@@ -84,17 +97,26 @@ func (ag *accountsGetter) GetLegacyDelegatorsAccounts() (map[string]*data.Accoun
 }
 
 // GetValidatorsAccounts will fetch all validators accounts
-func (ag *accountsGetter) GetValidatorsAccounts() (map[string]*data.AccountInfoWithStakeValues, error) {
+func (ag *accountsGetter) GetValidatorsAccounts(ctx context.Context, snapshotBlock *data.SnapshotBlock) (map[string]*data.AccountInfoWithStakeValues, error) {
 	defer logExecutionTime(time.Now(), "Fetched accounts from validators contract")
 
+	blockQuery, err := blockQueryForShard(nodeCore.MetachainShardId, snapshotBlock)
+	if err != nil {
+		return nil, err
+	}
+
 	genericApiResponse := &data.GenericAPIResponse{}
-	err := ag.restClient.CallGetRestEndPoint(pathValidatorsStake, genericApiResponse, ag.authenticationData)
+	err = ag.restClient.CallGetRestEndPoint(ctx, pathValidatorsStake+blockQuery, genericApiResponse, ag.authenticationData)
 	if err != nil {
 		return nil, err
 	}
 	if genericApiResponse.Error != "" {
 		return nil, fmt.Errorf("%s", genericApiResponse.Error)
 	}
+	err = checkResponseBlock(genericApiResponse.Data, blockQuery)
+	if err != nil {
+		return nil, err
+	}
 
 	list := gjson.Get(string(genericApiResponse.Data), "list")
 	accountsInfo := make([]data.StakedInfo, 0)
@@ -117,7 +139,7 @@ func (ag *accountsGetter) GetValidatorsAccounts() (map[string]*data.AccountInfoW
 
 	log.Info("validators accounts", "num", len(accountsStake))
 
-	err = ag.putUndelegatedValuesFromValidatorsContract(accountsStake)
+	err = ag.putUndelegatedValuesFromValidatorsContract(ctx, accountsStake, snapshotBlock)
 	if err != nil {
 		return nil, err
 	}
@@ -126,11 +148,16 @@ func (ag *accountsGetter) GetValidatorsAccounts() (map[string]*data.AccountInfoW
 }
 
 // GetDelegatorsAccounts will fetch all delegators accounts
-func (ag *accountsGetter) GetDelegatorsAccounts() (map[string]*data.AccountInfoWithStakeValues, error) {
+func (ag *accountsGetter) GetDelegatorsAccounts(ctx context.Context, snapshotBlock *data.SnapshotBlock) (map[string]*data.AccountInfoWithStakeValues, error) {
 	defer logExecutionTime(time.Now(), "Fetched accounts from delegation manager contracts")
 
+	blockQuery, err := blockQueryForShard(nodeCore.MetachainShardId, snapshotBlock)
+	if err != nil {
+		return nil, err
+	}
+
 	genericApiResponse := &data.GenericAPIResponse{}
-	err := ag.restClient.CallGetRestEndPoint(pathDelegatorStake, genericApiResponse, ag.authenticationData)
+	err = ag.restClient.CallGetRestEndPoint(ctx, pathDelegatorStake+blockQuery, genericApiResponse, ag.authenticationData)
 	if err != nil {
 		log.Warn("CallGetRestEndPoint", "error", err.Error())
 		return nil, err
@@ -138,6 +165,10 @@ func (ag *accountsGetter) GetDelegatorsAccounts() (map[string]*data.AccountInfoW
 	if genericApiResponse.Error != "" {
 		return nil, fmt.Errorf("cannot get delegators accounts %s", genericApiResponse.Error)
 	}
+	err = checkResponseBlock(genericApiResponse.Data, blockQuery)
+	if err != nil {
+		return nil, err
+	}
 
 	list := gjson.Get(string(genericApiResponse.Data), "list")
 	accountsInfo := make([]data.DelegatorStake, 0)
@@ -149,17 +180,27 @@ func (ag *accountsGetter) GetDelegatorsAccounts() (map[string]*data.AccountInfoW
 
 	accountsStake := make(map[string]*data.AccountInfoWithStakeValues)
 	for _, acct := range accountsInfo {
+		delegatedTo := make([]*data.DelegationInfo, 0, len(acct.DelegatedTo))
+		for _, delegation := range acct.DelegatedTo {
+			delegatedTo = append(delegatedTo, &data.DelegationInfo{
+				DelegationScAddress: delegation.DelegationScAddress,
+				Value:               delegation.Value,
+				ValueNum:            core.ComputeBalanceAsFloat(delegation.Value),
+			})
+		}
+
 		accountsStake[acct.DelegatorAddress] = &data.AccountInfoWithStakeValues{
 			StakeInfo: data.StakeInfo{
 				Delegation:    acct.Total,
 				DelegationNum: core.ComputeBalanceAsFloat(acct.Total),
+				DelegatedTo:   delegatedTo,
 			},
 		}
 	}
 
 	log.Info("delegators accounts", "num", len(accountsStake))
 
-	err = ag.unDelegatedInfoProc.putUnDelegateInfoFromStakingProviders(accountsStake)
+	err = ag.unDelegatedInfoProc.putUnDelegateInfoFromStakingProviders(ctx, accountsStake)
 	if err != nil {
 		return nil, err
 	}
@@ -168,7 +209,7 @@ func (ag *accountsGetter) GetDelegatorsAccounts() (map[string]*data.AccountInfoW
 }
 
 // GetLKMEXStakeAccounts will fetch all accounts that have stake lkmex tokens
-func (ag *accountsGetter) GetLKMEXStakeAccounts() (map[string]*data.AccountInfoWithStakeValues, error) {
+func (ag *accountsGetter) GetLKMEXStakeAccounts(ctx context.Context, snapshotBlock *data.SnapshotBlock) (map[string]*data.AccountInfoWithStakeValues, error) {
 	accountsMap := make(map[string]*data.AccountInfoWithStakeValues)
 	if ag.lkMexContractAddress == "" {
 		return accountsMap, nil
@@ -182,8 +223,13 @@ func (ag *accountsGetter) GetLKMEXStakeAccounts() (map[string]*data.AccountInfoW
 		CallerAddr: ag.lkMexContractAddress,
 	}
 
+	blockQuery, err := ag.blockQueryForAddress(ag.lkMexContractAddress, snapshotBlock)
+	if err != nil {
+		return nil, err
+	}
+
 	responseVmValue := &data.ResponseVmValue{}
-	err := ag.restClient.CallPostRestEndPoint(pathVMValues, vmRequest, responseVmValue, core.GetEmptyApiCredentials())
+	err = ag.restClient.CallPostRestEndPoint(ctx, pathVMValues+blockQuery, vmRequest, responseVmValue, core.GetEmptyApiCredentials())
 	if err != nil {
 		return nil, err
 	}
@@ -195,6 +241,10 @@ func (ag *accountsGetter) GetLKMEXStakeAccounts() (map[string]*data.AccountInfoW
 			return nil, fmt.Errorf("%s: %s", responseVmValue.Data.Data.ReturnCode, responseVmValue.Data.Data.ReturnMessage)
 		}
 	}
+	err = checkVmValuesResponseBlock(responseVmValue, blockQuery)
+	if err != nil {
+		return nil, err
+	}
 
 	stepForLoop := 2
 	returnedData := responseVmValue.Data.Data.ReturnData
@@ -220,6 +270,50 @@ func (ag *accountsGetter) GetLKMEXStakeAccounts() (map[string]*data.AccountInfoW
 	return accountsMap, nil
 }
 
+// blockQueryForAddress returns the query string that pins a request for the provided address to the snapshot block of
+// the address' shard
+func (ag *accountsGetter) blockQueryForAddress(address string, snapshotBlock *data.SnapshotBlock) (string, error) {
+	if snapshotBlock == nil {
+		return "", nil
+	}
+
+	decodedAddress, err := ag.pubKeyConverter.Decode(address)
+	if err != nil {
+		return "", err
+	}
+
+	return blockQueryForShard(core.ComputeShardID(decodedAddress, snapshotBlock.NumShards), snapshotBlock)
+}
+
+// checkResponseBlock verifies that a response that holds block info was read at the requested block. A response
+// without block info is rejected, as there is no proof that the gateway honored the requested block
+func checkResponseBlock(responseData []byte, blockQuery string) error {
+	return checkResponseNonce(gjson.Get(string(responseData), "blockInfo.nonce").String(), blockQuery)
+}
+
+// checkVmValuesResponseBlock verifies that a vm-values response was read at the requested block
+func checkVmValuesResponseBlock(response *data.ResponseVmValue, blockQuery string) error {
+	responseNonce := ""
+	if response.Data.BlockInfo != nil {
+		responseNonce = strconv.FormatUint(response.Data.BlockInfo.Nonce, 10)
+	}
+
+	return checkResponseNonce(responseNonce, blockQuery)
+}
+
+func checkResponseNonce(responseNonce string, blockQuery string) error {
+	if blockQuery == "" {
+		return nil
+	}
+
+	requestedNonce := strings.TrimPrefix(blockQuery, "?"+blockNonceQueryParam+"=")
+	if responseNonce != requestedNonce {
+		return fmt.Errorf("%w: requested block nonce %s, got %q", ErrSnapshotBlockNotHonored, requestedNonce, responseNonce)
+	}
+
+	return nil
+}
+
 func logExecutionTime(start time.Time, message string) {
 	log.Info(message, "duration in seconds", time.Since(start).Seconds())
 }
@@ -0,0 +1,78 @@
+package process
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core/pubkeyConverter"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountsGetter_GetDelegatorsAccountsKeepsTheStakePerProvider(t *testing.T) {
+	t.Parallel()
+
+	delegatedInfo := `{"list":[{"delegatorAddress":"erd1063s32hkyj55dpvhtsadacpt268angz2rh2wu4zwqe54awxz5q5sdg5e8z","delegatedTo":[
+		{"delegationScAddress":"erd1qqqqqqqqqqqqqqqpqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqhllllsajxzat","value":"1000000000000000000"},
+		{"delegationScAddress":"erd1qqqqqqqqqqqqqqqpqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqhllllsajxzab","value":"3000000000000000000"}
+	],"total":"4000000000000000000"}]}`
+
+	pubKeyConverter, _ := pubkeyConverter.NewBech32PubkeyConverter(32, log)
+	ag, err := NewAccountsGetter(&mocks.RestClientStub{
+		CallGetRestEndPointCalled: func(_ context.Context, path string, value interface{}, _ data.RestApiAuthenticationData) error {
+			require.Equal(t, pathDelegatorStake, path)
+			value.(*data.GenericAPIResponse).Data = []byte(delegatedInfo)
+			return nil
+		},
+	}, pubKeyConverter, data.RestApiAuthenticationData{}, config.GeneralConfig{}, &mocks.ElasticClientStub{
+		DoScrollRequestAllDocumentsCalled: func(index string, body []byte, handlerFunc func(responseBytes []byte) error) error {
+			return handlerFunc([]byte(readJson("./testdata/delegators-es.json")))
+		},
+	})
+	require.Nil(t, err)
+
+	accounts, err := ag.GetDelegatorsAccounts(context.Background(), nil)
+	require.Nil(t, err)
+	require.Len(t, accounts, 1)
+
+	account := accounts["erd1063s32hkyj55dpvhtsadacpt268angz2rh2wu4zwqe54awxz5q5sdg5e8z"]
+	require.Equal(t, "4000000000000000000", account.Delegation)
+	require.Equal(t, []*data.DelegationInfo{
+		{
+			DelegationScAddress: "erd1qqqqqqqqqqqqqqqpqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqhllllsajxzab",
+			Value:               "3000000000000000000",
+			ValueNum:            3,
+			UnDelegateValue:     "3000000000000000000",
+			UnDelegateValueNum:  3,
+		},
+		{
+			DelegationScAddress: "erd1qqqqqqqqqqqqqqqpqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqhllllsajxzat",
+			Value:               "1000000000000000000",
+			ValueNum:            1,
+			UnDelegateValue:     "7000000000000000000",
+			UnDelegateValueNum:  7,
+		},
+	}, account.DelegatedTo)
+}
+
+func TestAccountsGetter_GetDelegatorsAccountsShouldFailWhenTheBlockIsNotHonored(t *testing.T) {
+	t.Parallel()
+
+	pubKeyConverter, _ := pubkeyConverter.NewBech32PubkeyConverter(32, log)
+	ag, _ := NewAccountsGetter(&mocks.RestClientStub{
+		CallGetRestEndPointCalled: func(_ context.Context, path string, value interface{}, _ data.RestApiAuthenticationData) error {
+			require.Equal(t, pathDelegatorStake+"?blockNonce=100", path)
+			// the gateway ignores the block nonce and answers with the current state
+			value.(*data.GenericAPIResponse).Data = []byte(`{"list":[]}`)
+			return nil
+		},
+	}, pubKeyConverter, data.RestApiAuthenticationData{}, config.GeneralConfig{}, &mocks.ElasticClientStub{})
+
+	snapshotBlock := &data.SnapshotBlock{NumShards: 2, MetaBlock: &data.BlockInfo{Nonce: 100}}
+	accounts, err := ag.GetDelegatorsAccounts(context.Background(), snapshotBlock)
+	require.Nil(t, accounts)
+	require.True(t, errors.Is(err, ErrSnapshotBlockNotHonored))
+}
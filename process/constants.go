@@ -3,3 +3,11 @@ package process
 const (
 	accountsIndex = "accounts-000001"
 )
+
+const (
+	legacyDelegationSourceName = "legacy-delegation"
+	validatorsSourceName       = "validators"
+	delegatorsSourceName       = "delegators"
+	lkMexSourceName            = "lkmex"
+	energySourceName           = "energy"
+)
@@ -2,12 +2,13 @@ package process
 
 import (
 	"errors"
+	"os"
 
-	"github.com/multiversx/mx-chain-core-go/core/pubkeyConverter"
 	logger "github.com/multiversx/mx-chain-logger-go"
 	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
 	"github.com/multiversx/mx-chain-tools-accounts-manager-go/core"
 	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex/exporter"
 	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex/reindexer"
 	"github.com/multiversx/mx-chain-tools-accounts-manager-go/elasticClient"
 	"github.com/multiversx/mx-chain-tools-accounts-manager-go/restClient"
@@ -15,28 +16,24 @@ import (
 
 var log = logger.GetOrCreate("process")
 
-// CreateDataProcessor will create a new instance of a data processor
-func CreateDataProcessor(cfg *config.Config, indicesConfigPath string) (DataProcessor, error) {
-	return getReindexerDataProcessor(cfg, indicesConfigPath)
+// CreateDataProcessor will create a new instance of a data processor. In dry run mode, the data processor will not
+// write anything in the destination clusters and will print a summary instead
+func CreateDataProcessor(cfg *config.Config, indicesConfigPath string, dryRun bool, version string) (DataProcessor, error) {
+	return getReindexerDataProcessor(cfg, indicesConfigPath, dryRun, version)
 }
 
-func getReindexerDataProcessor(cfg *config.Config, indicesConfigPath string) (DataProcessor, error) {
+func getReindexerDataProcessor(cfg *config.Config, indicesConfigPath string, dryRun bool, version string) (DataProcessor, error) {
 	sourceEsClient, err := elasticClient.NewElasticClient(cfg.Reindexer.SourceElasticSearchClient)
 	if err != nil {
 		return nil, err
 	}
 
-	destinationESClients, err := createESClients(cfg)
-	if err != nil {
-		return nil, err
-	}
-
-	rClient, err := restClient.NewRestClient(cfg.APIConfig.URL)
+	rClient, err := restClient.NewRestClient(cfg.APIConfig)
 	if err != nil {
 		return nil, err
 	}
 
-	pubKeyConverter, err := pubkeyConverter.NewBech32PubkeyConverter(cfg.AddressPubkeyConverter.Length, log)
+	pubKeyConverter, err := core.NewPubkeyConverter(cfg.AddressPubkeyConverter)
 	if err != nil {
 		return nil, err
 	}
@@ -53,21 +50,82 @@ func getReindexerDataProcessor(cfg *config.Config, indicesConfigPath string) (Da
 		return nil, err
 	}
 
-	acctsProcessor, err := NewAccountsProcessor(rClient, acctGetter)
+	stakeSources, err := createStakeSources(cfg, acctGetter)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshotBlockResolver, err := NewSnapshotBlockResolver(rClient, cfg.SnapshotBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	acctsProcessor, err := NewAccountsProcessor(rClient, stakeSources, snapshotBlockResolver, cfg.StakeSources)
+	if err != nil {
+		return nil, err
+	}
+
+	reindexerProc, err := createReindexer(cfg, sourceEsClient, indicesConfigPath, dryRun)
+	if err != nil {
+		return nil, err
+	}
+
+	runReportFilePath := cfg.RunReport.FilePath
+	if dryRun {
+		runReportFilePath = ""
+	}
+
+	return NewReindexerDataProcessor(acctsProcessor, reindexerProc, runReportFilePath, version)
+}
+
+func createStakeSources(cfg *config.Config, acctGetter AccountsGetterHandler) ([]StakeSource, error) {
+	registry := NewStakeSourcesRegistry()
+	err := RegisterDefaultStakeSources(registry, acctGetter)
 	if err != nil {
 		return nil, err
 	}
 
-	reindexerProc, err := reindexer.New(sourceEsClient, destinationESClients, indicesConfigPath)
+	return registry.GetSources(cfg.StakeSources.Enabled)
+}
+
+func createReindexer(
+	cfg *config.Config,
+	sourceEsClient crossIndex.ElasticClientHandler,
+	indicesConfigPath string,
+	dryRun bool,
+) (Reindexer, error) {
+	if dryRun {
+		return reindexer.NewDryRun(sourceEsClient, cfg.Reindexer.SourceReader, os.Stdout)
+	}
+
+	destinationESClients, err := createESClients(cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	return NewReindexerDataProcessor(acctsProcessor, reindexerProc)
+	exporters, err := createExporters(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return reindexer.New(reindexer.ArgsReindexer{
+		SourceIndexer:       sourceEsClient,
+		DestinationIndexers: destinationESClients,
+		PathToIndicesConfig: indicesConfigPath,
+		AccountsAlias:       cfg.Destination.AccountsAlias,
+		CheckpointFilePath:  cfg.Reindexer.CheckpointFilePath,
+		SourceReader:        cfg.Reindexer.SourceReader,
+		Exporters:           exporters,
+		SanityGate:          cfg.Reindexer.SanityGate,
+		StakeHistoryEnabled: cfg.Destination.StakeHistory.Enabled,
+		WritePolicy:         cfg.Destination.WritePolicy,
+		BulkIndexer:         cfg.Destination.BulkIndexer,
+		LifecyclePolicy:     cfg.Destination.LifecyclePolicy,
+	})
 }
 
 func createESClients(cfg *config.Config) ([]crossIndex.ElasticClientHandler, error) {
-	if len(cfg.Destination.DestinationElasticSearchClients) == 0 {
+	if len(cfg.Destination.DestinationElasticSearchClients) == 0 && !cfg.Destination.Export.Enabled {
 		return nil, errors.New("empty destination clients array")
 	}
 
@@ -83,3 +141,16 @@ func createESClients(cfg *config.Config) ([]crossIndex.ElasticClientHandler, err
 
 	return clients, nil
 }
+
+func createExporters(cfg *config.Config) ([]crossIndex.AccountsExporter, error) {
+	if !cfg.Destination.Export.Enabled {
+		return nil, nil
+	}
+
+	fileExporter, err := exporter.NewFileExporter(cfg.Destination.Export, os.Stdout)
+	if err != nil {
+		return nil, err
+	}
+
+	return []crossIndex.AccountsExporter{fileExporter}, nil
+}
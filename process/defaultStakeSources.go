@@ -0,0 +1,110 @@
+package process
+
+import (
+	"context"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+)
+
+// RegisterDefaultStakeSources will register the legacy delegation, validators, delegators, lkmex and energy stake
+// sources. All of them use the provided accounts getter to fetch the accounts
+func RegisterDefaultStakeSources(registry *stakeSourcesRegistry, getter AccountsGetterHandler) error {
+	argsList := []ArgsStakeSource{
+		{
+			Name:  legacyDelegationSourceName,
+			Fetch: withoutBlockInfo(getter.GetLegacyDelegatorsAccounts),
+			Merge: func(destination *data.AccountInfoWithStakeValues, source *data.AccountInfoWithStakeValues) {
+				destination.DelegationLegacyActive = source.DelegationLegacyActive
+				destination.DelegationLegacyActiveNum = source.DelegationLegacyActiveNum
+				destination.DelegationLegacyWaiting = source.DelegationLegacyWaiting
+				destination.DelegationLegacyWaitingNum = source.DelegationLegacyWaitingNum
+
+				destination.UnDelegateLegacy = source.UnDelegateLegacy
+				destination.UnDelegateLegacyNum = source.UnDelegateLegacyNum
+			},
+			StakeValues: func(account *data.AccountInfoWithStakeValues) []string {
+				return []string{account.DelegationLegacyWaiting, account.DelegationLegacyActive}
+			},
+			UnDelegatedValues: func(account *data.AccountInfoWithStakeValues) []string {
+				return []string{account.UnDelegateLegacy}
+			},
+		},
+		{
+			Name:  validatorsSourceName,
+			Fetch: withoutBlockInfo(getter.GetValidatorsAccounts),
+			Merge: func(destination *data.AccountInfoWithStakeValues, source *data.AccountInfoWithStakeValues) {
+				destination.ValidatorsActive = source.ValidatorsActive
+				destination.ValidatorsActiveNum = source.ValidatorsActiveNum
+				destination.ValidatorTopUp = source.ValidatorTopUp
+				destination.ValidatorTopUpNum = source.ValidatorTopUpNum
+
+				destination.UnDelegateValidator = source.UnDelegateValidator
+				destination.UnDelegateValidatorNum = source.UnDelegateValidatorNum
+			},
+			StakeValues: func(account *data.AccountInfoWithStakeValues) []string {
+				return []string{account.ValidatorsActive, account.ValidatorTopUp}
+			},
+			UnDelegatedValues: func(account *data.AccountInfoWithStakeValues) []string {
+				return []string{account.UnDelegateValidator}
+			},
+		},
+		{
+			Name:  delegatorsSourceName,
+			Fetch: withoutBlockInfo(getter.GetDelegatorsAccounts),
+			Merge: func(destination *data.AccountInfoWithStakeValues, source *data.AccountInfoWithStakeValues) {
+				destination.Delegation = source.Delegation
+				destination.DelegationNum = source.DelegationNum
+				destination.DelegatedTo = source.DelegatedTo
+
+				destination.UnDelegateDelegation = source.UnDelegateDelegation
+				destination.UnDelegateDelegationNum = source.UnDelegateDelegationNum
+			},
+			StakeValues: func(account *data.AccountInfoWithStakeValues) []string {
+				return []string{account.Delegation}
+			},
+			UnDelegatedValues: func(account *data.AccountInfoWithStakeValues) []string {
+				return []string{account.UnDelegateDelegation}
+			},
+		},
+		{
+			Name:  lkMexSourceName,
+			Fetch: withoutBlockInfo(getter.GetLKMEXStakeAccounts),
+			Merge: func(destination *data.AccountInfoWithStakeValues, source *data.AccountInfoWithStakeValues) {
+				destination.LKMEXStake = source.LKMEXStake
+				destination.LKMEXStakeNum = source.LKMEXStakeNum
+			},
+		},
+		{
+			Name:  energySourceName,
+			Fetch: getter.GetAccountsWithEnergy,
+			Merge: func(destination *data.AccountInfoWithStakeValues, source *data.AccountInfoWithStakeValues) {
+				destination.Energy = source.Energy
+				destination.EnergyNum = source.EnergyNum
+				destination.EnergyDetails = source.EnergyDetails
+			},
+		},
+	}
+
+	for _, args := range argsList {
+		source, err := NewStakeSource(args)
+		if err != nil {
+			return err
+		}
+
+		err = registry.Register(source)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func withoutBlockInfo(
+	getter func(ctx context.Context, snapshotBlock *data.SnapshotBlock) (map[string]*data.AccountInfoWithStakeValues, error),
+) func(ctx context.Context, epoch uint32, snapshotBlock *data.SnapshotBlock) (map[string]*data.AccountInfoWithStakeValues, *data.BlockInfo, error) {
+	return func(ctx context.Context, _ uint32, snapshotBlock *data.SnapshotBlock) (map[string]*data.AccountInfoWithStakeValues, *data.BlockInfo, error) {
+		accounts, err := getter(ctx, snapshotBlock)
+		return accounts, nil, err
+	}
+}
@@ -1,6 +1,7 @@
 package process
 
 import (
+	"context"
 	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
@@ -19,16 +20,25 @@ const (
 )
 
 // GetAccountsWithEnergy will return accounts with energy
-func (ag *accountsGetter) GetAccountsWithEnergy(currentEpoch uint32) (map[string]*data.AccountInfoWithStakeValues, *data.BlockInfo, error) {
+func (ag *accountsGetter) GetAccountsWithEnergy(
+	ctx context.Context,
+	currentEpoch uint32,
+	snapshotBlock *data.SnapshotBlock,
+) (map[string]*data.AccountInfoWithStakeValues, *data.BlockInfo, error) {
 	if ag.energyContractAddress == "" {
 		return map[string]*data.AccountInfoWithStakeValues{}, nil, nil
 	}
 
 	defer logExecutionTime(time.Now(), "Fetched accounts from energy contract")
 
+	blockQuery, err := ag.blockQueryForAddress(ag.energyContractAddress, snapshotBlock)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	genericAPIResponse := &data.GenericAPIResponse{}
-	path := fmt.Sprintf(pathAccountKeys, ag.energyContractAddress)
-	err := ag.restClient.CallGetRestEndPoint(path, genericAPIResponse, core.GetEmptyApiCredentials())
+	path := fmt.Sprintf(pathAccountKeys, ag.energyContractAddress) + blockQuery
+	err = ag.restClient.CallGetRestEndPoint(ctx, path, genericAPIResponse, core.GetEmptyApiCredentials())
 	if err != nil {
 		return nil, nil, err
 	}
@@ -36,6 +46,11 @@ func (ag *accountsGetter) GetAccountsWithEnergy(currentEpoch uint32) (map[string
 		return nil, nil, fmt.Errorf("cannot get accounts with energy %s", genericAPIResponse.Error)
 	}
 
+	err = checkResponseBlock(genericAPIResponse.Data, blockQuery)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	accountsWithEnergy, err := ag.extractAddressesAndEnergy(genericAPIResponse.Data, currentEpoch)
 	if err != nil {
 		return nil, nil, fmt.Errorf("cannot extract accounts with energy %s", err.Error())
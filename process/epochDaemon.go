@@ -0,0 +1,108 @@
+package process
+
+import (
+	"context"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+)
+
+type epochDaemon struct {
+	dataProcessor DataProcessor
+	pollInterval  time.Duration
+	settleDelay   time.Duration
+
+	observedEpoch      uint32
+	hasObservedEpoch   bool
+	lastProcessedEpoch uint32
+	hasProcessedEpoch  bool
+}
+
+// NewEpochDaemon will create a new instance of epochDaemon
+func NewEpochDaemon(dataProcessor DataProcessor, daemonConfig config.DaemonConfig) (*epochDaemon, error) {
+	if check.IfNil(dataProcessor) {
+		return nil, ErrNilDataProcessor
+	}
+	if daemonConfig.PollIntervalInSeconds <= 0 {
+		return nil, ErrInvalidPollInterval
+	}
+	if daemonConfig.SettleDelayInSeconds < 0 {
+		return nil, ErrInvalidSettleDelay
+	}
+
+	return &epochDaemon{
+		dataProcessor: dataProcessor,
+		pollInterval:  time.Duration(daemonConfig.PollIntervalInSeconds) * time.Second,
+		settleDelay:   time.Duration(daemonConfig.SettleDelayInSeconds) * time.Second,
+	}, nil
+}
+
+// Run will poll the current epoch and will process the accounts data once for every new epoch, until the provided
+// context is done. A failed epoch is retried on the next poll
+func (ed *epochDaemon) Run(ctx context.Context) error {
+	log.Info("starting daemon mode", "poll interval", ed.pollInterval, "settle delay", ed.settleDelay)
+
+	for {
+		ed.checkEpoch(time.Now())
+
+		select {
+		case <-ctx.Done():
+			log.Info("daemon mode stopped")
+			return nil
+		case <-time.After(ed.pollInterval):
+		}
+	}
+}
+
+func (ed *epochDaemon) checkEpoch(now time.Time) {
+	epochStatus, err := ed.dataProcessor.GetCurrentEpochStatus()
+	if err != nil {
+		log.Warn("cannot get current epoch", "error", err)
+		return
+	}
+
+	epoch := epochStatus.Epoch
+
+	if ed.hasProcessedEpoch && ed.lastProcessedEpoch == epoch {
+		return
+	}
+
+	if !ed.hasObservedEpoch || ed.observedEpoch != epoch {
+		log.Info("observed new epoch", "epoch", epoch, "started at", epochStatus.StartTime)
+		ed.observedEpoch = epoch
+		ed.hasObservedEpoch = true
+	}
+
+	processed, err := ed.dataProcessor.IsEpochProcessed(epoch)
+	if err != nil {
+		log.Warn("cannot check if epoch was already processed", "epoch", epoch, "error", err)
+		return
+	}
+	if processed {
+		log.Info("epoch was already processed, skipping", "epoch", epoch)
+		ed.markEpochAsProcessed(epoch)
+		return
+	}
+
+	// the settle delay is counted from the start of the epoch, so it does not depend on when the daemon was started
+	sinceEpochStart := now.Sub(epochStatus.StartTime)
+	if sinceEpochStart < ed.settleDelay {
+		log.Debug("waiting for the epoch to settle", "epoch", epoch, "remaining", ed.settleDelay-sinceEpochStart)
+		return
+	}
+
+	err = ed.dataProcessor.ProcessAccountsDataForEpoch(epoch)
+	if err != nil {
+		log.Error("cannot process accounts data, will retry", "epoch", epoch, "error", err)
+		return
+	}
+
+	log.Info("processed accounts data", "epoch", epoch)
+	ed.markEpochAsProcessed(epoch)
+}
+
+func (ed *epochDaemon) markEpochAsProcessed(epoch uint32) {
+	ed.lastProcessedEpoch = epoch
+	ed.hasProcessedEpoch = true
+}
@@ -0,0 +1,128 @@
+package process
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEpochDaemon(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewEpochDaemon(nil, config.DaemonConfig{PollIntervalInSeconds: 1})
+	require.Equal(t, ErrNilDataProcessor, err)
+
+	_, err = NewEpochDaemon(&mocks.DataProcessorStub{}, config.DaemonConfig{})
+	require.Equal(t, ErrInvalidPollInterval, err)
+
+	_, err = NewEpochDaemon(&mocks.DataProcessorStub{}, config.DaemonConfig{PollIntervalInSeconds: 1, SettleDelayInSeconds: -1})
+	require.Equal(t, ErrInvalidSettleDelay, err)
+
+	ed, err := NewEpochDaemon(&mocks.DataProcessorStub{}, config.DaemonConfig{PollIntervalInSeconds: 1})
+	require.Nil(t, err)
+	require.NotNil(t, ed)
+}
+
+func TestEpochDaemon_CheckEpochWaitsSettleDelayAndProcessesOnce(t *testing.T) {
+	t.Parallel()
+
+	start := time.Now()
+	epochStatus := &data.EpochStatus{Epoch: 10, StartTime: start}
+	processedEpochs := make([]uint32, 0)
+	ed, _ := NewEpochDaemon(&mocks.DataProcessorStub{
+		GetCurrentEpochStatusCalled: func() (*data.EpochStatus, error) {
+			return epochStatus, nil
+		},
+		ProcessAccountsDataForEpochCalled: func(epoch uint32) error {
+			processedEpochs = append(processedEpochs, epoch)
+			return nil
+		},
+	}, config.DaemonConfig{PollIntervalInSeconds: 1, SettleDelayInSeconds: 60})
+
+	ed.checkEpoch(start)
+	require.Empty(t, processedEpochs)
+
+	ed.checkEpoch(start.Add(59 * time.Second))
+	require.Empty(t, processedEpochs)
+
+	ed.checkEpoch(start.Add(60 * time.Second))
+	require.Equal(t, []uint32{10}, processedEpochs)
+
+	ed.checkEpoch(start.Add(120 * time.Second))
+	require.Equal(t, []uint32{10}, processedEpochs)
+
+	epochStatus = &data.EpochStatus{Epoch: 11, StartTime: start.Add(130 * time.Second)}
+	ed.checkEpoch(start.Add(130 * time.Second))
+	require.Equal(t, []uint32{10}, processedEpochs)
+
+	ed.checkEpoch(start.Add(190 * time.Second))
+	require.Equal(t, []uint32{10, 11}, processedEpochs)
+}
+
+func TestEpochDaemon_CheckEpochSkipsProcessedEpochsAndRetriesFailures(t *testing.T) {
+	t.Parallel()
+
+	numProcessCalls := 0
+	processErr := errors.New("local error")
+	ed, _ := NewEpochDaemon(&mocks.DataProcessorStub{
+		GetCurrentEpochStatusCalled: func() (*data.EpochStatus, error) {
+			return &data.EpochStatus{Epoch: 5}, nil
+		},
+		IsEpochProcessedCalled: func(epoch uint32) (bool, error) {
+			return epoch == 4, nil
+		},
+		ProcessAccountsDataForEpochCalled: func(epoch uint32) error {
+			numProcessCalls++
+			return processErr
+		},
+	}, config.DaemonConfig{PollIntervalInSeconds: 1})
+
+	now := time.Now()
+	ed.checkEpoch(now)
+	ed.checkEpoch(now)
+	require.Equal(t, 2, numProcessCalls)
+
+	processErr = nil
+	ed.checkEpoch(now)
+	ed.checkEpoch(now)
+	require.Equal(t, 3, numProcessCalls)
+
+	ed, _ = NewEpochDaemon(&mocks.DataProcessorStub{
+		GetCurrentEpochStatusCalled: func() (*data.EpochStatus, error) {
+			return &data.EpochStatus{Epoch: 4}, nil
+		},
+		IsEpochProcessedCalled: func(epoch uint32) (bool, error) {
+			return epoch == 4, nil
+		},
+		ProcessAccountsDataForEpochCalled: func(epoch uint32) error {
+			require.Fail(t, "should not have been called")
+			return nil
+		},
+	}, config.DaemonConfig{PollIntervalInSeconds: 1})
+	ed.checkEpoch(now)
+}
+
+func TestEpochDaemon_CheckEpochCountsTheSettleDelayFromTheEpochStart(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	processedEpochs := make([]uint32, 0)
+	ed, _ := NewEpochDaemon(&mocks.DataProcessorStub{
+		GetCurrentEpochStatusCalled: func() (*data.EpochStatus, error) {
+			// the daemon was started long after the epoch started
+			return &data.EpochStatus{Epoch: 10, StartTime: now.Add(-time.Hour)}, nil
+		},
+		ProcessAccountsDataForEpochCalled: func(epoch uint32) error {
+			processedEpochs = append(processedEpochs, epoch)
+			return nil
+		},
+	}, config.DaemonConfig{PollIntervalInSeconds: 1, SettleDelayInSeconds: 60})
+
+	ed.checkEpoch(now)
+	require.Equal(t, []uint32{10}, processedEpochs)
+}
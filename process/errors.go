@@ -13,3 +13,54 @@ var ErrNilReindexer = errors.New("nil reindexer")
 
 // ErrNilCloner signals that a nil cloner has been provided
 var ErrNilCloner = errors.New("nil cloner")
+
+// ErrNilDataProcessor signals that a nil data processor has been provided
+var ErrNilDataProcessor = errors.New("nil data processor")
+
+// ErrInvalidPollInterval signals that an invalid poll interval has been provided
+var ErrInvalidPollInterval = errors.New("invalid poll interval")
+
+// ErrInvalidSettleDelay signals that an invalid settle delay has been provided
+var ErrInvalidSettleDelay = errors.New("invalid settle delay")
+
+// ErrInvalidSourceTimeout signals that an invalid stake source timeout has been provided
+var ErrInvalidSourceTimeout = errors.New("invalid stake source timeout")
+
+// ErrNilStakeSource signals that a nil stake source has been provided
+var ErrNilStakeSource = errors.New("nil stake source")
+
+// ErrNoStakeSource signals that no stake source has been provided
+var ErrNoStakeSource = errors.New("no stake source")
+
+// ErrEmptyStakeSourceName signals that a stake source without a name has been provided
+var ErrEmptyStakeSourceName = errors.New("empty stake source name")
+
+// ErrNilFetchFunction signals that a nil fetch function has been provided
+var ErrNilFetchFunction = errors.New("nil fetch function")
+
+// ErrNilMergeFunction signals that a nil merge function has been provided
+var ErrNilMergeFunction = errors.New("nil merge function")
+
+// ErrStakeSourceAlreadyRegistered signals that a stake source with the same name was already registered
+var ErrStakeSourceAlreadyRegistered = errors.New("stake source already registered")
+
+// ErrUnknownStakeSource signals that a stake source that was not registered has been requested
+var ErrUnknownStakeSource = errors.New("unknown stake source")
+
+// ErrDuplicatedStakeSource signals that the same stake source has been requested more than once
+var ErrDuplicatedStakeSource = errors.New("duplicated stake source")
+
+// ErrNilRestClient signals that a nil rest client has been provided
+var ErrNilRestClient = errors.New("nil rest client")
+
+// ErrNilSnapshotBlockResolver signals that a nil snapshot block resolver has been provided
+var ErrNilSnapshotBlockResolver = errors.New("nil snapshot block resolver")
+
+// ErrInvalidSnapshotBlock signals that the snapshot block cannot be used
+var ErrInvalidSnapshotBlock = errors.New("invalid snapshot block")
+
+// ErrInvalidEpochStart signals that the start time of the epoch cannot be computed
+var ErrInvalidEpochStart = errors.New("invalid epoch start")
+
+// ErrSnapshotBlockNotHonored signals that the gateway returned data from a different block than the requested one
+var ErrSnapshotBlockNotHonored = errors.New("snapshot block not honored by the gateway")
@@ -2,7 +2,9 @@ package process
 
 import (
 	"bytes"
+	"context"
 
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex/pruner"
 	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
 )
 
@@ -10,6 +12,7 @@ import (
 type ElasticClientHandler interface {
 	PutMapping(targetIndex string, body *bytes.Buffer) error
 	DoBulkRequest(buff *bytes.Buffer, index string) error
+	DoBulkRequestWithItems(buff *bytes.Buffer, index string) (*data.BulkRequestResponse, error)
 	DoMultiGet(ids []string, index string) ([]byte, error)
 	DoScrollRequestAllDocuments(index string, body []byte, handlerFunc func(responseBytes []byte) error) error
 	IsInterfaceNil() bool
@@ -17,8 +20,8 @@ type ElasticClientHandler interface {
 
 // RestClientHandler defines what a rest client should be able to do
 type RestClientHandler interface {
-	CallGetRestEndPoint(path string, value interface{}, authenticationData data.RestApiAuthenticationData) error
-	CallPostRestEndPoint(path string, data interface{}, response interface{}, authenticationData data.RestApiAuthenticationData) error
+	CallGetRestEndPoint(ctx context.Context, path string, value interface{}, authenticationData data.RestApiAuthenticationData) error
+	CallPostRestEndPoint(ctx context.Context, path string, data interface{}, response interface{}, authenticationData data.RestApiAuthenticationData) error
 }
 
 // AccountsIndexerHandler defines what an accounts indexer should be able to do
@@ -31,6 +34,7 @@ type AccountsIndexerHandler interface {
 // AccountsProcessorHandler defines what an accounts processor should be able to do
 type AccountsProcessorHandler interface {
 	GetCurrentEpoch() (uint32, error)
+	GetCurrentEpochStatus() (*data.EpochStatus, error)
 	GetAllAccountsWithStake(uint32) (*data.AccountsData, error)
 	ComputeClonedAccountsIndex(uint32) (string, error)
 	IsInterfaceNil() bool
@@ -38,11 +42,27 @@ type AccountsProcessorHandler interface {
 
 // AccountsGetterHandler defines what an accounts getter should be able to do
 type AccountsGetterHandler interface {
-	GetLegacyDelegatorsAccounts() (map[string]*data.AccountInfoWithStakeValues, error)
-	GetValidatorsAccounts() (map[string]*data.AccountInfoWithStakeValues, error)
-	GetDelegatorsAccounts() (map[string]*data.AccountInfoWithStakeValues, error)
-	GetLKMEXStakeAccounts() (map[string]*data.AccountInfoWithStakeValues, error)
-	GetAccountsWithEnergy(currentEpoch uint32) (map[string]*data.AccountInfoWithStakeValues, *data.BlockInfo, error)
+	GetLegacyDelegatorsAccounts(ctx context.Context, snapshotBlock *data.SnapshotBlock) (map[string]*data.AccountInfoWithStakeValues, error)
+	GetValidatorsAccounts(ctx context.Context, snapshotBlock *data.SnapshotBlock) (map[string]*data.AccountInfoWithStakeValues, error)
+	GetDelegatorsAccounts(ctx context.Context, snapshotBlock *data.SnapshotBlock) (map[string]*data.AccountInfoWithStakeValues, error)
+	GetLKMEXStakeAccounts(ctx context.Context, snapshotBlock *data.SnapshotBlock) (map[string]*data.AccountInfoWithStakeValues, error)
+	GetAccountsWithEnergy(ctx context.Context, currentEpoch uint32, snapshotBlock *data.SnapshotBlock) (map[string]*data.AccountInfoWithStakeValues, *data.BlockInfo, error)
+}
+
+// StakeSource defines what a source of accounts with stake should be able to do
+type StakeSource interface {
+	Name() string
+	FetchAccounts(ctx context.Context, epoch uint32, snapshotBlock *data.SnapshotBlock) (map[string]*data.AccountInfoWithStakeValues, *data.BlockInfo, error)
+	MergeAccount(destination *data.AccountInfoWithStakeValues, source *data.AccountInfoWithStakeValues)
+	TotalStakeValues(account *data.AccountInfoWithStakeValues) []string
+	TotalUnDelegatedValues(account *data.AccountInfoWithStakeValues) []string
+	IsInterfaceNil() bool
+}
+
+// SnapshotBlockResolver defines what a snapshot block resolver should be able to do
+type SnapshotBlockResolver interface {
+	ResolveSnapshotBlock(ctx context.Context, epoch uint32) (*data.SnapshotBlock, error)
+	IsInterfaceNil() bool
 }
 
 // Cloner defines what a clone should be able to do
@@ -53,11 +73,24 @@ type Cloner interface {
 
 // Reindexer defines what a reindexer should be able to do
 type Reindexer interface {
-	ReindexAccounts(sourceIndex string, destinationIndex string, accountsData *data.AccountsData) error
+	ReindexAccounts(sourceIndex string, destinationIndex string, accountsData *data.AccountsData, report *data.RunReport) error
+	IndexRunReport(report *data.RunReport) error
+	WasReindexed(destinationIndex string, epoch uint32) (bool, error)
 	IsInterfaceNil() bool
 }
 
 // DataProcessor defines what a data processor should be able to do
 type DataProcessor interface {
 	ProcessAccountsData() error
+	ProcessAccountsDataForEpoch(epoch uint32) error
+	GetCurrentEpochStatus() (*data.EpochStatus, error)
+	IsEpochProcessed(epoch uint32) (bool, error)
+	IsInterfaceNil() bool
+}
+
+// Pruner defines what an accounts indices pruner should be able to do
+type Pruner interface {
+	Plan() ([]*pruner.IndexDecision, error)
+	Prune(decisions []*pruner.IndexDecision) error
+	IsInterfaceNil() bool
 }
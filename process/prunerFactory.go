@@ -0,0 +1,22 @@
+package process
+
+import (
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex/pruner"
+)
+
+// CreatePruner will create the pruner of the accounts indices, over the destination clusters of the reindexer
+func CreatePruner(cfg *config.Config, keepLast int, keepEpochs uint32) (Pruner, error) {
+	destinationESClients, err := createESClients(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return pruner.NewPruner(pruner.ArgsPruner{
+		DestinationClients:  destinationESClients,
+		AccountsIndexPrefix: accountsIndex,
+		AccountsAlias:       cfg.Destination.AccountsAlias,
+		KeepLast:            keepLast,
+		KeepEpochs:          keepEpochs,
+	})
+}
@@ -0,0 +1,47 @@
+package process
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/api"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/elasticClient"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/process/accountsIndexer"
+)
+
+// CreateQueryAPIHandler will create the http handler of the read-only query API. The API reads the accounts from the
+// first destination cluster
+func CreateQueryAPIHandler(cfg *config.Config) (http.Handler, error) {
+	if len(cfg.Destination.DestinationElasticSearchClients) == 0 {
+		return nil, errors.New("empty destination clients array")
+	}
+
+	esClient, err := elasticClient.NewElasticClient(cfg.Destination.DestinationElasticSearchClients[0])
+	if err != nil {
+		return nil, err
+	}
+
+	acIndexer, err := accountsIndexer.NewAccountsIndexer(esClient, cfg.Destination.BulkIndexer)
+	if err != nil {
+		return nil, err
+	}
+
+	index := cfg.QueryAPI.Index
+	if index == "" {
+		index = cfg.Destination.AccountsAlias
+	}
+
+	queryAPI, err := api.NewQueryAPI(api.ArgsQueryAPI{
+		ElasticClient:  esClient,
+		AccountsGetter: acIndexer,
+		Index:          index,
+		MaxBatchSize:   cfg.QueryAPI.MaxBatchSize,
+		MaxTopSize:     cfg.QueryAPI.MaxTopSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return queryAPI.Handler(), nil
+}
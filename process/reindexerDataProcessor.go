@@ -1,16 +1,27 @@
 package process
 
-import "github.com/multiversx/mx-chain-core-go/core/check"
+import (
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/metrics"
+)
 
 type reindexerDataProcessor struct {
 	accountsProcessor AccountsProcessorHandler
 	reindexer         Reindexer
+	runReportFilePath string
+	version           string
 }
 
-// NewReindexerDataProcessor will create a new instance of reindexerDataProcessor
+// NewReindexerDataProcessor will create a new instance of reindexerDataProcessor. The report of every run is saved by
+// the reindexer and, if the provided file path is not empty, in a local JSON file
 func NewReindexerDataProcessor(
 	accountsProcessor AccountsProcessorHandler,
 	reindexer Reindexer,
+	runReportFilePath string,
+	version string,
 ) (*reindexerDataProcessor, error) {
 	if check.IfNil(accountsProcessor) {
 		return nil, ErrNilAccountsProcessor
@@ -22,25 +33,108 @@ func NewReindexerDataProcessor(
 	return &reindexerDataProcessor{
 		accountsProcessor: accountsProcessor,
 		reindexer:         reindexer,
+		runReportFilePath: runReportFilePath,
+		version:           version,
 	}, nil
 }
 
-// ProcessAccountsData will process accounts data
+// ProcessAccountsData will process accounts data for the current epoch
 func (dp *reindexerDataProcessor) ProcessAccountsData() error {
 	epoch, err := dp.accountsProcessor.GetCurrentEpoch()
 	if err != nil {
 		return err
 	}
 
+	return dp.ProcessAccountsDataForEpoch(epoch)
+}
+
+// ProcessAccountsDataForEpoch will process accounts data for the provided epoch. A report of the run is saved at the
+// end, whether the run succeeded or not
+func (dp *reindexerDataProcessor) ProcessAccountsDataForEpoch(epoch uint32) error {
+	report := &data.RunReport{
+		Epoch:        epoch,
+		Version:      dp.version,
+		StartTime:    time.Now(),
+		Sources:      make([]data.SourceInfo, 0),
+		Destinations: make([]*data.DestinationReport, 0),
+		Warnings:     make([]string, 0),
+	}
+
+	err := dp.processAccountsDataForEpoch(epoch, report)
+
+	report.EndTime = time.Now()
+	report.DurationInSeconds = report.EndTime.Sub(report.StartTime).Seconds()
+	report.Status = data.RunStatusSuccess
+	if err != nil {
+		report.Status = data.RunStatusFailure
+		report.Error = err.Error()
+	}
+
+	metrics.ObserveRun(epoch, report.EndTime.Sub(report.StartTime), err)
+	dp.saveRunReport(report)
+
+	return err
+}
+
+func (dp *reindexerDataProcessor) processAccountsDataForEpoch(epoch uint32, report *data.RunReport) error {
 	accountsRest, err := dp.accountsProcessor.GetAllAccountsWithStake(epoch)
 	if err != nil {
 		return err
 	}
 
+	report.Sources = accountsRest.Sources
+	for _, source := range accountsRest.Sources {
+		if source.Error != "" {
+			report.AddWarning("the optional stake source failed, its accounts are left out of the snapshot",
+				"source", source.Name, "error", source.Error)
+		}
+	}
+	report.SnapshotBlock = accountsRest.SnapshotBlock
+	report.NumAccountsWithStake = len(accountsRest.AccountsWithStake)
+
 	newIndex, err := dp.accountsProcessor.ComputeClonedAccountsIndex(epoch)
 	if err != nil {
 		return err
 	}
 
-	return dp.reindexer.ReindexAccounts(accountsIndex, newIndex, accountsRest)
+	report.Index = newIndex
+
+	return dp.reindexer.ReindexAccounts(accountsIndex, newIndex, accountsRest, report)
+}
+
+// saveRunReport will save the provided report. A report that cannot be saved does not fail the run
+func (dp *reindexerDataProcessor) saveRunReport(report *data.RunReport) {
+	err := dp.reindexer.IndexRunReport(report)
+	if err != nil {
+		log.Warn("cannot index the run report", "epoch", report.Epoch, "error", err)
+	}
+
+	if dp.runReportFilePath == "" {
+		return
+	}
+
+	err = writeRunReportFile(dp.runReportFilePath, report)
+	if err != nil {
+		log.Warn("cannot write the run report file", "epoch", report.Epoch, "error", err)
+	}
+}
+
+// GetCurrentEpochStatus will return the current epoch of the network and the time at which it started
+func (dp *reindexerDataProcessor) GetCurrentEpochStatus() (*data.EpochStatus, error) {
+	return dp.accountsProcessor.GetCurrentEpochStatus()
+}
+
+// IsEpochProcessed returns true if the accounts index for the provided epoch was already fully indexed
+func (dp *reindexerDataProcessor) IsEpochProcessed(epoch uint32) (bool, error) {
+	newIndex, err := dp.accountsProcessor.ComputeClonedAccountsIndex(epoch)
+	if err != nil {
+		return false, err
+	}
+
+	return dp.reindexer.WasReindexed(newIndex, epoch)
+}
+
+// IsInterfaceNil returns true if the value under the interface is nil
+func (dp *reindexerDataProcessor) IsInterfaceNil() bool {
+	return dp == nil
 }
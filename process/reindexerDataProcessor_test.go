@@ -0,0 +1,109 @@
+package process
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReindexerDataProcessor_ProcessAccountsDataForEpochWritesRunReport(t *testing.T) {
+	t.Parallel()
+
+	reportFilePath := filepath.Join(t.TempDir(), "run-report.json")
+	accountsProcessor := &mocks.AccountsProcessorStub{
+		GetAllAccountsWithStakeCalled: func(epoch uint32) (*data.AccountsData, error) {
+			return &data.AccountsData{
+				AccountsWithStake: map[string]*data.AccountInfoWithStakeValues{"erd1a": {}, "erd1b": {}},
+				Epoch:             epoch,
+				Sources: []data.SourceInfo{
+					{Name: "validators", NumAccounts: 2, DurationInSeconds: 1.5},
+					{Name: "lkmex", Error: "timeout"},
+				},
+			}, nil
+		},
+		ComputeClonedAccountsIndexCalled: func(epoch uint32) (string, error) {
+			return "accounts-000001_10", nil
+		},
+	}
+
+	var indexedReport *data.RunReport
+	reindexerStub := &mocks.ReindexerStub{
+		ReindexAccountsCalled: func(_ string, destinationIndex string, _ *data.AccountsData, report *data.RunReport) error {
+			require.Equal(t, "accounts-000001_10", destinationIndex)
+			report.AddWarning("cannot prepare the destination index", "destination", "cluster-1", "error", "run report test")
+
+			report.NumMergedAccounts = 5
+			report.Destinations = []*data.DestinationReport{{Name: "cluster-0", NumIndexedAccounts: 5}}
+			return nil
+		},
+		IndexRunReportCalled: func(report *data.RunReport) error {
+			indexedReport = report
+			return nil
+		},
+	}
+
+	dp, err := NewReindexerDataProcessor(accountsProcessor, reindexerStub, reportFilePath, "v1.2.3")
+	require.Nil(t, err)
+
+	err = dp.ProcessAccountsDataForEpoch(10)
+	require.Nil(t, err)
+
+	reportBytes, err := ioutil.ReadFile(reportFilePath)
+	require.Nil(t, err)
+	report := &data.RunReport{}
+	err = json.Unmarshal(reportBytes, report)
+	require.Nil(t, err)
+
+	require.Equal(t, uint32(10), report.Epoch)
+	require.Equal(t, "v1.2.3", report.Version)
+	require.Equal(t, data.RunStatusSuccess, report.Status)
+	require.Equal(t, "accounts-000001_10", report.Index)
+	require.Equal(t, 2, report.NumAccountsWithStake)
+	require.Equal(t, 5, report.NumMergedAccounts)
+	require.Len(t, report.Sources, 2)
+	require.Equal(t, 5, report.Destinations[0].NumIndexedAccounts)
+	require.False(t, report.EndTime.Before(report.StartTime))
+	require.Equal(t, report.Epoch, indexedReport.Epoch)
+
+	require.Equal(t, []string{
+		"the optional stake source failed, its accounts are left out of the snapshot source = lkmex error = timeout",
+		"cannot prepare the destination index destination = cluster-1 error = run report test",
+	}, report.Warnings)
+}
+
+func TestReindexerDataProcessor_ProcessAccountsDataForEpochReportsFailure(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("gateway error")
+	accountsProcessor := &mocks.AccountsProcessorStub{
+		GetAllAccountsWithStakeCalled: func(epoch uint32) (*data.AccountsData, error) {
+			return nil, expectedErr
+		},
+	}
+
+	var indexedReport *data.RunReport
+	reindexerStub := &mocks.ReindexerStub{
+		ReindexAccountsCalled: func(_ string, _ string, _ *data.AccountsData, _ *data.RunReport) error {
+			require.Fail(t, "should have not been called")
+			return nil
+		},
+		IndexRunReportCalled: func(report *data.RunReport) error {
+			indexedReport = report
+			return errors.New("cannot index the report")
+		},
+	}
+
+	dp, _ := NewReindexerDataProcessor(accountsProcessor, reindexerStub, "", "v1.2.3")
+
+	err := dp.ProcessAccountsDataForEpoch(11)
+	require.Equal(t, expectedErr, err)
+	require.Equal(t, data.RunStatusFailure, indexedReport.Status)
+	require.Equal(t, expectedErr.Error(), indexedReport.Error)
+	require.Empty(t, indexedReport.Sources)
+}
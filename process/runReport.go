@@ -0,0 +1,26 @@
+package process
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+)
+
+// writeRunReportFile will write the provided report in a temporary file and then will replace the report file with it
+func writeRunReportFile(filePath string, report *data.RunReport) error {
+	reportBytes, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmpFilePath := filePath + ".tmp"
+	err = ioutil.WriteFile(tmpFilePath, reportBytes, 0644)
+	if err != nil {
+		return fmt.Errorf("cannot write run report file %s: %w", tmpFilePath, err)
+	}
+
+	return os.Rename(tmpFilePath, filePath)
+}
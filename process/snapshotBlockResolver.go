@@ -0,0 +1,232 @@
+package process
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	nodeCore "github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/core"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/tidwall/gjson"
+)
+
+const (
+	pathNetworkConfig    = "/network/config"
+	pathHyperblockNonce  = "/hyperblock/by-nonce/%d"
+	blockNonceQueryParam = "blockNonce"
+
+	// SnapshotBlockModeNone will read every stake source at the block that is current when its request lands
+	SnapshotBlockModeNone = "none"
+	// SnapshotBlockModeLatest will read all the stake sources at the latest metachain block
+	SnapshotBlockModeLatest = "latest"
+	// SnapshotBlockModeEpochStart will read all the stake sources at the first metachain block of the epoch
+	SnapshotBlockModeEpochStart = "epoch-start"
+	// SnapshotBlockModeNonce will read all the stake sources at the metachain block with the configured nonce
+	SnapshotBlockModeNonce = "nonce"
+
+	// maxHyperblocksLookBack is the number of previous metachain blocks searched for a shard that has no block
+	// notarized in the snapshot metachain block
+	maxHyperblocksLookBack = 50
+)
+
+type hyperblock struct {
+	Hash        string `json:"hash"`
+	Nonce       uint64 `json:"nonce"`
+	Epoch       uint32 `json:"epoch"`
+	ShardBlocks []struct {
+		Hash  string `json:"hash"`
+		Nonce uint64 `json:"nonce"`
+		Shard uint32 `json:"shard"`
+	} `json:"shardBlocks"`
+}
+
+type snapshotBlockResolver struct {
+	restClient RestClientHandler
+	mode       string
+	nonce      uint64
+}
+
+// NewSnapshotBlockResolver will create a new instance of snapshotBlockResolver
+func NewSnapshotBlockResolver(restClient RestClientHandler, cfg config.SnapshotBlockConfig) (*snapshotBlockResolver, error) {
+	if restClient == nil {
+		return nil, ErrNilRestClient
+	}
+
+	mode := cfg.Mode
+	if mode == "" {
+		mode = SnapshotBlockModeNone
+	}
+
+	switch mode {
+	case SnapshotBlockModeNone, SnapshotBlockModeLatest, SnapshotBlockModeEpochStart:
+	case SnapshotBlockModeNonce:
+		if cfg.Nonce == 0 {
+			return nil, fmt.Errorf("%w: the nonce must be set when the mode is %s", ErrInvalidSnapshotBlock, mode)
+		}
+	default:
+		return nil, fmt.Errorf("%w: unknown mode %s", ErrInvalidSnapshotBlock, mode)
+	}
+
+	return &snapshotBlockResolver{
+		restClient: restClient,
+		mode:       mode,
+		nonce:      cfg.Nonce,
+	}, nil
+}
+
+// ResolveSnapshotBlock will return the blocks at which all the stake sources should be read for the provided epoch.
+// It returns nil if the stake sources should not be pinned to a block
+func (sr *snapshotBlockResolver) ResolveSnapshotBlock(ctx context.Context, epoch uint32) (*data.SnapshotBlock, error) {
+	if sr.mode == SnapshotBlockModeNone {
+		return nil, nil
+	}
+
+	metaNonce, err := sr.getMetaNonce(ctx, epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	numShards, err := sr.getNumShards(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	metaBlock, err := sr.getHyperblock(ctx, metaNonce)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshotBlock := &data.SnapshotBlock{
+		Epoch:       metaBlock.Epoch,
+		NumShards:   numShards,
+		MetaBlock:   &data.BlockInfo{Hash: metaBlock.Hash, Nonce: metaBlock.Nonce},
+		ShardBlocks: make(map[uint32]*data.BlockInfo),
+	}
+
+	err = sr.putShardBlocks(ctx, snapshotBlock, metaBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("resolved snapshot block", "mode", sr.mode, "epoch", snapshotBlock.Epoch,
+		"meta nonce", snapshotBlock.MetaBlock.Nonce, "meta hash", snapshotBlock.MetaBlock.Hash)
+
+	return snapshotBlock, nil
+}
+
+func (sr *snapshotBlockResolver) getMetaNonce(ctx context.Context, epoch uint32) (uint64, error) {
+	if sr.mode == SnapshotBlockModeNonce {
+		return sr.nonce, nil
+	}
+
+	genericAPIResponse := &data.GenericAPIResponse{}
+	err := sr.restClient.CallGetRestEndPoint(ctx, pathNodeStatusMeta, genericAPIResponse, core.GetEmptyApiCredentials())
+	if err != nil {
+		return 0, err
+	}
+	if genericAPIResponse.Error != "" {
+		return 0, fmt.Errorf("cannot get metachain status %s", genericAPIResponse.Error)
+	}
+
+	status := string(genericAPIResponse.Data)
+	if sr.mode == SnapshotBlockModeLatest {
+		return gjson.Get(status, "status.erd_nonce").Uint(), nil
+	}
+
+	currentEpoch := uint32(gjson.Get(status, "status.erd_epoch_number").Uint())
+	if currentEpoch != epoch {
+		return 0, fmt.Errorf("%w: cannot use the start of epoch %d, the network is in epoch %d", ErrInvalidSnapshotBlock, epoch, currentEpoch)
+	}
+
+	return gjson.Get(status, "status.erd_nonce_at_epoch_start").Uint(), nil
+}
+
+func (sr *snapshotBlockResolver) getNumShards(ctx context.Context) (uint32, error) {
+	genericAPIResponse := &data.GenericAPIResponse{}
+	err := sr.restClient.CallGetRestEndPoint(ctx, pathNetworkConfig, genericAPIResponse, core.GetEmptyApiCredentials())
+	if err != nil {
+		return 0, err
+	}
+	if genericAPIResponse.Error != "" {
+		return 0, fmt.Errorf("cannot get network config %s", genericAPIResponse.Error)
+	}
+
+	numShards := uint32(gjson.Get(string(genericAPIResponse.Data), "config.erd_num_shards_without_meta").Uint())
+	if numShards == 0 {
+		return 0, fmt.Errorf("%w: cannot get the number of shards", ErrInvalidSnapshotBlock)
+	}
+
+	return numShards, nil
+}
+
+func (sr *snapshotBlockResolver) getHyperblock(ctx context.Context, nonce uint64) (*hyperblock, error) {
+	genericAPIResponse := &data.GenericAPIResponse{}
+	err := sr.restClient.CallGetRestEndPoint(ctx, fmt.Sprintf(pathHyperblockNonce, nonce), genericAPIResponse, core.GetEmptyApiCredentials())
+	if err != nil {
+		return nil, err
+	}
+	if genericAPIResponse.Error != "" {
+		return nil, fmt.Errorf("cannot get hyperblock with nonce %d %s", nonce, genericAPIResponse.Error)
+	}
+
+	block := &hyperblock{}
+	err = json.Unmarshal([]byte(gjson.Get(string(genericAPIResponse.Data), "hyperblock").Raw), block)
+	if err != nil {
+		return nil, fmt.Errorf("cannot unmarshal hyperblock with nonce %d, error: %w", nonce, err)
+	}
+
+	return block, nil
+}
+
+// putShardBlocks uses the latest shard blocks notarized by the metachain up to the snapshot metachain block
+func (sr *snapshotBlockResolver) putShardBlocks(ctx context.Context, snapshotBlock *data.SnapshotBlock, metaBlock *hyperblock) error {
+	block := metaBlock
+	for lookBack := 0; ; lookBack++ {
+		for _, shardBlock := range block.ShardBlocks {
+			_, found := snapshotBlock.ShardBlocks[shardBlock.Shard]
+			if found {
+				continue
+			}
+
+			snapshotBlock.ShardBlocks[shardBlock.Shard] = &data.BlockInfo{Hash: shardBlock.Hash, Nonce: shardBlock.Nonce}
+		}
+
+		if uint32(len(snapshotBlock.ShardBlocks)) >= snapshotBlock.NumShards {
+			return nil
+		}
+		if lookBack >= maxHyperblocksLookBack || block.Nonce == 0 {
+			return fmt.Errorf("%w: cannot find notarized blocks for all the shards before metachain nonce %d",
+				ErrInvalidSnapshotBlock, metaBlock.Nonce)
+		}
+
+		var err error
+		block, err = sr.getHyperblock(ctx, block.Nonce-1)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// IsInterfaceNil returns true if the value under the interface is nil
+func (sr *snapshotBlockResolver) IsInterfaceNil() bool {
+	return sr == nil
+}
+
+// blockQueryForShard returns the query string that pins a request to the snapshot block of the provided shard
+func blockQueryForShard(shardID uint32, snapshotBlock *data.SnapshotBlock) (string, error) {
+	if snapshotBlock == nil {
+		return "", nil
+	}
+
+	block := snapshotBlock.MetaBlock
+	if shardID != nodeCore.MetachainShardId {
+		block = snapshotBlock.ShardBlocks[shardID]
+	}
+	if block == nil {
+		return "", fmt.Errorf("%w: no block for shard %d", ErrInvalidSnapshotBlock, shardID)
+	}
+
+	return fmt.Sprintf("?%s=%d", blockNonceQueryParam, block.Nonce), nil
+}
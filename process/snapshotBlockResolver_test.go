@@ -0,0 +1,134 @@
+package process
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	nodeCore "github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func createGatewayStub(responses map[string]string) *mocks.RestClientStub {
+	return &mocks.RestClientStub{
+		CallGetRestEndPointCalled: func(_ context.Context, path string, value interface{}, _ data.RestApiAuthenticationData) error {
+			response, ok := responses[path]
+			if !ok {
+				return fmt.Errorf("unexpected path %s", path)
+			}
+
+			value.(*data.GenericAPIResponse).Data = []byte(response)
+			return nil
+		},
+	}
+}
+
+func TestNewSnapshotBlockResolver(t *testing.T) {
+	t.Parallel()
+
+	resolver, err := NewSnapshotBlockResolver(nil, config.SnapshotBlockConfig{})
+	require.Nil(t, resolver)
+	require.Equal(t, ErrNilRestClient, err)
+
+	resolver, err = NewSnapshotBlockResolver(&mocks.RestClientStub{}, config.SnapshotBlockConfig{Mode: "unknown"})
+	require.Nil(t, resolver)
+	require.True(t, errors.Is(err, ErrInvalidSnapshotBlock))
+
+	resolver, err = NewSnapshotBlockResolver(&mocks.RestClientStub{}, config.SnapshotBlockConfig{Mode: SnapshotBlockModeNonce})
+	require.Nil(t, resolver)
+	require.True(t, errors.Is(err, ErrInvalidSnapshotBlock))
+
+	resolver, err = NewSnapshotBlockResolver(&mocks.RestClientStub{}, config.SnapshotBlockConfig{})
+	require.Nil(t, err)
+
+	snapshotBlock, err := resolver.ResolveSnapshotBlock(context.Background(), 10)
+	require.Nil(t, err)
+	require.Nil(t, snapshotBlock)
+}
+
+func TestSnapshotBlockResolver_ResolveSnapshotBlockEpochStart(t *testing.T) {
+	t.Parallel()
+
+	restClient := createGatewayStub(map[string]string{
+		pathNodeStatusMeta: `{"status":{"erd_epoch_number":10,"erd_nonce":150,"erd_nonce_at_epoch_start":100}}`,
+		pathNetworkConfig:  `{"config":{"erd_num_shards_without_meta":2}}`,
+		"/hyperblock/by-nonce/100": `{"hyperblock":{"hash":"m100","nonce":100,"epoch":10,"shardBlocks":[
+			{"hash":"s0-80","nonce":80,"shard":0}
+		]}}`,
+		"/hyperblock/by-nonce/99": `{"hyperblock":{"hash":"m99","nonce":99,"epoch":9,"shardBlocks":[
+			{"hash":"s0-79","nonce":79,"shard":0},
+			{"hash":"s1-85","nonce":85,"shard":1}
+		]}}`,
+	})
+
+	resolver, _ := NewSnapshotBlockResolver(restClient, config.SnapshotBlockConfig{Mode: SnapshotBlockModeEpochStart})
+	snapshotBlock, err := resolver.ResolveSnapshotBlock(context.Background(), 10)
+	require.Nil(t, err)
+	require.Equal(t, &data.SnapshotBlock{
+		Epoch:     10,
+		NumShards: 2,
+		MetaBlock: &data.BlockInfo{Hash: "m100", Nonce: 100},
+		ShardBlocks: map[uint32]*data.BlockInfo{
+			0: {Hash: "s0-80", Nonce: 80},
+			1: {Hash: "s1-85", Nonce: 85},
+		},
+	}, snapshotBlock)
+
+	snapshotBlock, err = resolver.ResolveSnapshotBlock(context.Background(), 11)
+	require.Nil(t, snapshotBlock)
+	require.True(t, errors.Is(err, ErrInvalidSnapshotBlock))
+}
+
+func TestBlockQueryForShard(t *testing.T) {
+	t.Parallel()
+
+	query, err := blockQueryForShard(0, nil)
+	require.Nil(t, err)
+	require.Empty(t, query)
+
+	snapshotBlock := &data.SnapshotBlock{
+		MetaBlock:   &data.BlockInfo{Nonce: 100},
+		ShardBlocks: map[uint32]*data.BlockInfo{0: {Nonce: 80}},
+	}
+
+	query, err = blockQueryForShard(nodeCore.MetachainShardId, snapshotBlock)
+	require.Nil(t, err)
+	require.Equal(t, "?blockNonce=100", query)
+
+	query, err = blockQueryForShard(0, snapshotBlock)
+	require.Nil(t, err)
+	require.Equal(t, "?blockNonce=80", query)
+
+	_, err = blockQueryForShard(1, snapshotBlock)
+	require.True(t, errors.Is(err, ErrInvalidSnapshotBlock))
+}
+
+func TestCheckResponseBlock(t *testing.T) {
+	t.Parallel()
+
+	require.Nil(t, checkResponseBlock([]byte(`{}`), ""))
+	require.Nil(t, checkResponseBlock([]byte(`{"blockInfo":{"nonce":80}}`), "?blockNonce=80"))
+
+	err := checkResponseBlock([]byte(`{"blockInfo":{"nonce":81}}`), "?blockNonce=80")
+	require.True(t, errors.Is(err, ErrSnapshotBlockNotHonored))
+
+	err = checkResponseBlock([]byte(`{"list":[]}`), "?blockNonce=80")
+	require.True(t, errors.Is(err, ErrSnapshotBlockNotHonored))
+}
+
+func TestCheckVmValuesResponseBlock(t *testing.T) {
+	t.Parallel()
+
+	response := &data.ResponseVmValue{}
+	require.Nil(t, checkVmValuesResponseBlock(response, ""))
+
+	err := checkVmValuesResponseBlock(response, "?blockNonce=80")
+	require.True(t, errors.Is(err, ErrSnapshotBlockNotHonored))
+
+	response.Data.BlockInfo = &data.BlockInfo{Nonce: 80}
+	require.Nil(t, checkVmValuesResponseBlock(response, "?blockNonce=80"))
+}
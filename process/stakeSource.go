@@ -0,0 +1,92 @@
+package process
+
+import (
+	"context"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+)
+
+// ArgsStakeSource holds the arguments needed to create a stake source
+type ArgsStakeSource struct {
+	Name string
+	// Fetch returns the accounts of the source. The block info is optional
+	Fetch func(ctx context.Context, epoch uint32, snapshotBlock *data.SnapshotBlock) (map[string]*data.AccountInfoWithStakeValues, *data.BlockInfo, error)
+	// Merge copies the fields owned by the source into an account that was already fetched from a previous source
+	Merge func(destination *data.AccountInfoWithStakeValues, source *data.AccountInfoWithStakeValues)
+	// StakeValues returns the values of an account that count toward the total stake. Can be nil
+	StakeValues func(account *data.AccountInfoWithStakeValues) []string
+	// UnDelegatedValues returns the values of an account that count toward the total undelegated. Can be nil
+	UnDelegatedValues func(account *data.AccountInfoWithStakeValues) []string
+}
+
+type stakeSource struct {
+	name              string
+	fetch             func(ctx context.Context, epoch uint32, snapshotBlock *data.SnapshotBlock) (map[string]*data.AccountInfoWithStakeValues, *data.BlockInfo, error)
+	merge             func(destination *data.AccountInfoWithStakeValues, source *data.AccountInfoWithStakeValues)
+	stakeValues       func(account *data.AccountInfoWithStakeValues) []string
+	unDelegatedValues func(account *data.AccountInfoWithStakeValues) []string
+}
+
+// NewStakeSource will create a new instance of stakeSource
+func NewStakeSource(args ArgsStakeSource) (*stakeSource, error) {
+	if args.Name == "" {
+		return nil, ErrEmptyStakeSourceName
+	}
+	if args.Fetch == nil {
+		return nil, ErrNilFetchFunction
+	}
+	if args.Merge == nil {
+		return nil, ErrNilMergeFunction
+	}
+
+	return &stakeSource{
+		name:              args.Name,
+		fetch:             args.Fetch,
+		merge:             args.Merge,
+		stakeValues:       args.StakeValues,
+		unDelegatedValues: args.UnDelegatedValues,
+	}, nil
+}
+
+// Name returns the name of the stake source
+func (ss *stakeSource) Name() string {
+	return ss.name
+}
+
+// FetchAccounts will fetch the accounts of the stake source. If the snapshot block is not nil, the accounts should be
+// read at that block
+func (ss *stakeSource) FetchAccounts(
+	ctx context.Context,
+	epoch uint32,
+	snapshotBlock *data.SnapshotBlock,
+) (map[string]*data.AccountInfoWithStakeValues, *data.BlockInfo, error) {
+	return ss.fetch(ctx, epoch, snapshotBlock)
+}
+
+// MergeAccount will copy the fields owned by the stake source from the source account into the destination account
+func (ss *stakeSource) MergeAccount(destination *data.AccountInfoWithStakeValues, source *data.AccountInfoWithStakeValues) {
+	ss.merge(destination, source)
+}
+
+// TotalStakeValues returns the values of the account that count toward the total stake
+func (ss *stakeSource) TotalStakeValues(account *data.AccountInfoWithStakeValues) []string {
+	if ss.stakeValues == nil {
+		return nil
+	}
+
+	return ss.stakeValues(account)
+}
+
+// TotalUnDelegatedValues returns the values of the account that count toward the total undelegated
+func (ss *stakeSource) TotalUnDelegatedValues(account *data.AccountInfoWithStakeValues) []string {
+	if ss.unDelegatedValues == nil {
+		return nil
+	}
+
+	return ss.unDelegatedValues(account)
+}
+
+// IsInterfaceNil returns true if the value under the interface is nil
+func (ss *stakeSource) IsInterfaceNil() bool {
+	return ss == nil
+}
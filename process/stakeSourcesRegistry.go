@@ -0,0 +1,82 @@
+package process
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+)
+
+type stakeSourcesRegistry struct {
+	mutex   sync.RWMutex
+	sources []StakeSource
+	names   map[string]struct{}
+}
+
+// NewStakeSourcesRegistry will create a new instance of stakeSourcesRegistry
+func NewStakeSourcesRegistry() *stakeSourcesRegistry {
+	return &stakeSourcesRegistry{
+		sources: make([]StakeSource, 0),
+		names:   make(map[string]struct{}),
+	}
+}
+
+// Register will add a stake source in the registry. The names of the stake sources must be unique
+func (sr *stakeSourcesRegistry) Register(source StakeSource) error {
+	if check.IfNil(source) {
+		return ErrNilStakeSource
+	}
+
+	sr.mutex.Lock()
+	defer sr.mutex.Unlock()
+
+	_, found := sr.names[source.Name()]
+	if found {
+		return fmt.Errorf("%w: %s", ErrStakeSourceAlreadyRegistered, source.Name())
+	}
+
+	sr.names[source.Name()] = struct{}{}
+	sr.sources = append(sr.sources, source)
+
+	return nil
+}
+
+// GetSources returns the stake sources with the provided names, in the provided order. If no name is provided, all
+// the registered stake sources are returned, in the registration order
+func (sr *stakeSourcesRegistry) GetSources(names []string) ([]StakeSource, error) {
+	sr.mutex.RLock()
+	defer sr.mutex.RUnlock()
+
+	if len(names) == 0 {
+		return append([]StakeSource{}, sr.sources...), nil
+	}
+
+	sources := make([]StakeSource, 0, len(names))
+	usedNames := make(map[string]struct{})
+	for _, name := range names {
+		source, found := sr.getSource(name)
+		if !found {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownStakeSource, name)
+		}
+
+		_, used := usedNames[name]
+		if used {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicatedStakeSource, name)
+		}
+		usedNames[name] = struct{}{}
+
+		sources = append(sources, source)
+	}
+
+	return sources, nil
+}
+
+func (sr *stakeSourcesRegistry) getSource(name string) (StakeSource, bool) {
+	for _, source := range sr.sources {
+		if source.Name() == name {
+			return source, true
+		}
+	}
+
+	return nil, false
+}
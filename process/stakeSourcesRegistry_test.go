@@ -0,0 +1,113 @@
+package process
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func createStakeSourceWithName(t *testing.T, name string) StakeSource {
+	source, err := NewStakeSource(ArgsStakeSource{
+		Name: name,
+		Fetch: func(_ context.Context, _ uint32, _ *data.SnapshotBlock) (map[string]*data.AccountInfoWithStakeValues, *data.BlockInfo, error) {
+			return nil, nil, nil
+		},
+		Merge: func(_ *data.AccountInfoWithStakeValues, _ *data.AccountInfoWithStakeValues) {},
+	})
+	require.Nil(t, err)
+
+	return source
+}
+
+func TestNewStakeSource(t *testing.T) {
+	t.Parallel()
+
+	source, err := NewStakeSource(ArgsStakeSource{})
+	require.Nil(t, source)
+	require.Equal(t, ErrEmptyStakeSourceName, err)
+
+	source, err = NewStakeSource(ArgsStakeSource{Name: "source"})
+	require.Nil(t, source)
+	require.Equal(t, ErrNilFetchFunction, err)
+
+	source, err = NewStakeSource(ArgsStakeSource{
+		Name: "source",
+		Fetch: func(_ context.Context, _ uint32, _ *data.SnapshotBlock) (map[string]*data.AccountInfoWithStakeValues, *data.BlockInfo, error) {
+			return nil, nil, nil
+		},
+	})
+	require.Nil(t, source)
+	require.Equal(t, ErrNilMergeFunction, err)
+
+	source2 := createStakeSourceWithName(t, "source")
+	require.Equal(t, "source", source2.Name())
+	require.Nil(t, source2.TotalStakeValues(&data.AccountInfoWithStakeValues{}))
+	require.Nil(t, source2.TotalUnDelegatedValues(&data.AccountInfoWithStakeValues{}))
+}
+
+func TestStakeSourcesRegistry_Register(t *testing.T) {
+	t.Parallel()
+
+	registry := NewStakeSourcesRegistry()
+	require.Equal(t, ErrNilStakeSource, registry.Register(nil))
+
+	require.Nil(t, registry.Register(createStakeSourceWithName(t, "a")))
+	err := registry.Register(createStakeSourceWithName(t, "a"))
+	require.True(t, errors.Is(err, ErrStakeSourceAlreadyRegistered))
+}
+
+func TestStakeSourcesRegistry_GetSources(t *testing.T) {
+	t.Parallel()
+
+	registry := NewStakeSourcesRegistry()
+	require.Nil(t, registry.Register(createStakeSourceWithName(t, "a")))
+	require.Nil(t, registry.Register(createStakeSourceWithName(t, "b")))
+	require.Nil(t, registry.Register(createStakeSourceWithName(t, "c")))
+
+	sources, err := registry.GetSources(nil)
+	require.Nil(t, err)
+	require.Equal(t, []string{"a", "b", "c"}, getSourcesNames(sources))
+
+	sources, err = registry.GetSources([]string{"c", "a"})
+	require.Nil(t, err)
+	require.Equal(t, []string{"c", "a"}, getSourcesNames(sources))
+
+	sources, err = registry.GetSources([]string{"a", "d"})
+	require.Nil(t, sources)
+	require.True(t, errors.Is(err, ErrUnknownStakeSource))
+
+	sources, err = registry.GetSources([]string{"a", "a"})
+	require.Nil(t, sources)
+	require.True(t, errors.Is(err, ErrDuplicatedStakeSource))
+}
+
+func TestRegisterDefaultStakeSources(t *testing.T) {
+	t.Parallel()
+
+	registry := NewStakeSourcesRegistry()
+	err := RegisterDefaultStakeSources(registry, &mocks.AccountsGetterStub{})
+	require.Nil(t, err)
+
+	sources, err := registry.GetSources(nil)
+	require.Nil(t, err)
+	require.Equal(t, []string{
+		legacyDelegationSourceName,
+		validatorsSourceName,
+		delegatorsSourceName,
+		lkMexSourceName,
+		energySourceName,
+	}, getSourcesNames(sources))
+}
+
+func getSourcesNames(sources []StakeSource) []string {
+	names := make([]string, 0, len(sources))
+	for _, source := range sources {
+		names = append(names, source.Name())
+	}
+
+	return names
+}
@@ -1,8 +1,10 @@
 package process
 
 import (
+	"context"
 	"encoding/json"
 	"math/big"
+	"sort"
 	"time"
 
 	"github.com/multiversx/mx-chain-es-indexer-go/process/dataindexer"
@@ -30,6 +32,7 @@ type delegatorsResponse struct {
 			ID     string `json:"_id"`
 			Source struct {
 				Address         string `json:"address"`
+				Contract        string `json:"contract"`
 				UnDelegatedInfo []struct {
 					Value string `json:"value"`
 				} `json:"unDelegateInfo"`
@@ -48,9 +51,15 @@ func newUnDelegateInfoProcessor(esClient ElasticClientHandler) *unDelegatedInfoP
 	}
 }
 
-func (up *unDelegatedInfoProcessor) putUnDelegateInfoFromStakingProviders(accountsWithStake map[string]*data.AccountInfoWithStakeValues) error {
+// putUnDelegateInfoFromStakingProviders will read the undelegated values from the delegators index. The scroll does not
+// take a context, so the context is checked before every page in order to stop the reading once it is cancelled
+func (up *unDelegatedInfoProcessor) putUnDelegateInfoFromStakingProviders(ctx context.Context, accountsWithStake map[string]*data.AccountInfoWithStakeValues) error {
 	defer logExecutionTime(time.Now(), "Fetched undelegated values from staking provider contracts")
 	handlerFunc := func(responseBytes []byte) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
 		delegatorsResp := &delegatorsResponse{}
 		err := json.Unmarshal(responseBytes, delegatorsResp)
 		if err != nil {
@@ -66,7 +75,16 @@ func (up *unDelegatedInfoProcessor) putUnDelegateInfoFromStakingProviders(accoun
 		return nil
 	}
 
-	return up.esClient.DoScrollRequestAllDocuments(dataindexer.DelegatorsIndex, []byte(queryGetDelegatorsWithUnDelegateInfo), handlerFunc)
+	err := up.esClient.DoScrollRequestAllDocuments(dataindexer.DelegatorsIndex, []byte(queryGetDelegatorsWithUnDelegateInfo), handlerFunc)
+	if err != nil {
+		return err
+	}
+
+	for _, accountWithStake := range accountsWithStake {
+		sortDelegatedTo(accountWithStake.DelegatedTo)
+	}
+
+	return nil
 }
 
 func extractDataFromResponseAndPutInAccountsWithStake(delegatorsResp *delegatorsResponse, accountsWithStake map[string]*data.AccountInfoWithStakeValues) {
@@ -87,9 +105,44 @@ func extractDataFromResponseAndPutInAccountsWithStake(delegatorsResp *delegators
 		}
 
 		setUnDelegateValue(accountWithStake, undelegatedValue)
+		setUnDelegateValueForProvider(accountWithStake, delegatorInfo.Source.Contract, undelegatedValue)
 	}
 }
 
+func setUnDelegateValueForProvider(account *data.AccountInfoWithStakeValues, provider string, undelegatedValue *big.Int) {
+	if provider == "" {
+		return
+	}
+
+	var delegationInfo *data.DelegationInfo
+	for _, info := range account.DelegatedTo {
+		if info.DelegationScAddress == provider {
+			delegationInfo = info
+			break
+		}
+	}
+	if delegationInfo == nil {
+		// the account has only undelegated tokens with this staking provider
+		delegationInfo = &data.DelegationInfo{DelegationScAddress: provider}
+		account.DelegatedTo = append(account.DelegatedTo, delegationInfo)
+	}
+
+	valueBig, ok := big.NewInt(0).SetString(delegationInfo.UnDelegateValue, 10)
+	if !ok {
+		valueBig = big.NewInt(0)
+	}
+
+	valueBig.Add(valueBig, undelegatedValue)
+	delegationInfo.UnDelegateValue = valueBig.String()
+	delegationInfo.UnDelegateValueNum = core.ComputeBalanceAsFloat(valueBig.String())
+}
+
+func sortDelegatedTo(delegatedTo []*data.DelegationInfo) {
+	sort.Slice(delegatedTo, func(i, j int) bool {
+		return delegatedTo[i].DelegationScAddress < delegatedTo[j].DelegationScAddress
+	})
+}
+
 func setUnDelegateValue(account *data.AccountInfoWithStakeValues, undelegatedValue *big.Int) {
 	if account.UnDelegateDelegation == "" {
 		account.UnDelegateDelegation = undelegatedValue.String()
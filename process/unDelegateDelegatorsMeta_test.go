@@ -1,6 +1,7 @@
 package process
 
 import (
+	"context"
 	"encoding/json"
 	"testing"
 
@@ -28,7 +29,7 @@ func TestAccountsGetter_DelegationMetaPutUnDelegatedValues(t *testing.T) {
 	err = json.Unmarshal([]byte(accountsWithStakeJson), &accountsWithStake)
 	require.Nil(t, err)
 
-	err = ag.unDelegatedInfoProc.putUnDelegateInfoFromStakingProviders(accountsWithStake)
+	err = ag.unDelegatedInfoProc.putUnDelegateInfoFromStakingProviders(context.Background(), accountsWithStake)
 	require.Nil(t, err)
 
 	accounts1 := accountsWithStake["erd102hpxzdawtka2usnmkqsk58v3k70jprhy50u4kdgc44j5azd6q5q7nn7f2"]
@@ -38,4 +39,40 @@ func TestAccountsGetter_DelegationMetaPutUnDelegatedValues(t *testing.T) {
 	accounts2 := accountsWithStake["erd1063s32hkyj55dpvhtsadacpt268angz2rh2wu4zwqe54awxz5q5sdg5e8z"]
 	require.Equal(t, accounts2.UnDelegateDelegation, "10000000000000000000")
 	require.Equal(t, accounts2.UnDelegateDelegationNum, float64(10))
+
+	require.Equal(t, []*data.DelegationInfo{
+		{
+			DelegationScAddress: "erd1qqqqqqqqqqqqqqqpqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqhllllsajxzat",
+			UnDelegateValue:     "2000000000000000000",
+			UnDelegateValueNum:  2,
+		},
+	}, accounts1.DelegatedTo)
+	require.Len(t, accounts2.DelegatedTo, 2)
+	require.Equal(t, "erd1qqqqqqqqqqqqqqqpqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqhllllsajxzab", accounts2.DelegatedTo[0].DelegationScAddress)
+	require.Equal(t, "erd1qqqqqqqqqqqqqqqpqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqhllllsajxzat", accounts2.DelegatedTo[1].DelegationScAddress)
+	require.Equal(t, "7000000000000000000", accounts2.DelegatedTo[1].UnDelegateValue)
+}
+
+func TestAccountsGetter_DelegationMetaShouldStopTheScrollWhenCancelled(t *testing.T) {
+	t.Parallel()
+	pubKeyConverter, _ := pubkeyConverter.NewBech32PubkeyConverter(32, log)
+
+	numPages := 0
+	ag, _ := NewAccountsGetter(&mocks.RestClientStub{}, pubKeyConverter, data.RestApiAuthenticationData{}, config.GeneralConfig{}, &mocks.ElasticClientStub{
+		DoScrollRequestAllDocumentsCalled: func(index string, body []byte, handlerFunc func(responseBytes []byte) error) error {
+			for {
+				err := handlerFunc([]byte(`{"hits":{"hits":[]}}`))
+				if err != nil {
+					return err
+				}
+				numPages++
+			}
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := ag.unDelegatedInfoProc.putUnDelegateInfoFromStakingProviders(ctx, make(map[string]*data.AccountInfoWithStakeValues))
+	require.Equal(t, context.Canceled, err)
+	require.Equal(t, 0, numPages)
 }
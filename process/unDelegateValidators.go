@@ -1,6 +1,7 @@
 package process
 
 import (
+	"context"
 	"encoding/hex"
 	"fmt"
 	"math/big"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/multiversx/mx-chain-tools-accounts-manager-go/core"
 	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/metrics"
 	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
 )
 
@@ -17,12 +19,21 @@ const (
 	getUnStakedTokensListEndpoint = "getUnStakedTokensList"
 )
 
-func (ag *accountsGetter) putUndelegatedValuesFromValidatorsContract(accountsWithStake map[string]*data.AccountInfoWithStakeValues) error {
+func (ag *accountsGetter) putUndelegatedValuesFromValidatorsContract(
+	ctx context.Context,
+	accountsWithStake map[string]*data.AccountInfoWithStakeValues,
+	snapshotBlock *data.SnapshotBlock,
+) error {
 	if ag.validatorsContract == "" {
 		return nil
 	}
 
-	unDelegatedValues, err := ag.getUnDelegatedValuesFromValidatorsContract(accountsWithStake)
+	blockQuery, err := ag.blockQueryForAddress(ag.validatorsContract, snapshotBlock)
+	if err != nil {
+		return err
+	}
+
+	unDelegatedValues, err := ag.getUnDelegatedValuesFromValidatorsContract(ctx, accountsWithStake, blockQuery)
 	if err != nil {
 		return err
 	}
@@ -39,7 +50,11 @@ func (ag *accountsGetter) putUndelegatedValuesFromValidatorsContract(accountsWit
 	return nil
 }
 
-func (ag *accountsGetter) getUnDelegatedValuesFromValidatorsContract(accountsWithStake map[string]*data.AccountInfoWithStakeValues) (map[string]*big.Int, error) {
+func (ag *accountsGetter) getUnDelegatedValuesFromValidatorsContract(
+	ctx context.Context,
+	accountsWithStake map[string]*data.AccountInfoWithStakeValues,
+	blockQuery string,
+) (map[string]*big.Int, error) {
 	defer logExecutionTime(time.Now(), "Fetched undelegated values from validators contract")
 
 	unDelegatedValue := make(map[string]*big.Int)
@@ -47,16 +62,22 @@ func (ag *accountsGetter) getUnDelegatedValuesFromValidatorsContract(accountsWit
 	done, wg := make(chan struct{}, maxNumberOfParallelRequests), &sync.WaitGroup{}
 	errors := make([]string, 0)
 	for address := range accountsWithStake {
+		if ctx.Err() != nil {
+			break
+		}
+
 		done <- struct{}{}
 		wg.Add(1)
 
 		go func(addr string) {
+			metrics.VMQueryStarted()
 			defer func() {
+				metrics.VMQueryDone()
 				<-done
 				wg.Done()
 			}()
 
-			value, err := ag.getUnDelegatedValueForAddressValidatorsContract(addr)
+			value, err := ag.getUnDelegatedValueForAddressValidatorsContract(ctx, addr, blockQuery)
 			if err != nil {
 				ag.mutex.Lock()
 				errors = append(errors, err.Error())
@@ -78,12 +99,14 @@ func (ag *accountsGetter) getUnDelegatedValuesFromValidatorsContract(accountsWit
 	if len(errors) > 0 {
 		return nil, fmt.Errorf("%s", errors[0])
 	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
 
 	return unDelegatedValue, nil
 }
 
-func (ag *accountsGetter) getUnDelegatedValueForAddressValidatorsContract(address string) (*big.Int, error) {
-
+func (ag *accountsGetter) getUnDelegatedValueForAddressValidatorsContract(ctx context.Context, address string, blockQuery string) (*big.Int, error) {
 	decodedAddr, err := ag.pubKeyConverter.Decode(address)
 	if err != nil {
 		return nil, err
@@ -97,7 +120,7 @@ func (ag *accountsGetter) getUnDelegatedValueForAddressValidatorsContract(addres
 	}
 
 	responseVmValue := &data.ResponseVmValue{}
-	err = ag.restClient.CallPostRestEndPoint(pathVMValues, vmRequest, responseVmValue, ag.authenticationData)
+	err = ag.restClient.CallPostRestEndPoint(ctx, pathVMValues+blockQuery, vmRequest, responseVmValue, ag.authenticationData)
 	if err != nil {
 		return nil, err
 	}
@@ -109,6 +132,10 @@ func (ag *accountsGetter) getUnDelegatedValueForAddressValidatorsContract(addres
 			return nil, fmt.Errorf("%s: %s", responseVmValue.Data.Data.ReturnCode, responseVmValue.Data.Data.ReturnMessage)
 		}
 	}
+	err = checkVmValuesResponseBlock(responseVmValue, blockQuery)
+	if err != nil {
+		return nil, err
+	}
 
 	if responseVmValue.Data.Data == nil {
 		return big.NewInt(0), nil
@@ -1,6 +1,7 @@
 package process
 
 import (
+	"context"
 	"encoding/json"
 	"math/big"
 	"testing"
@@ -20,7 +21,7 @@ func TestAccountsGetter_ValidatorsAccountsPutUnDelegatedValues(t *testing.T) {
 	pubKeyConverter, _ := pubkeyConverter.NewBech32PubkeyConverter(32, log)
 
 	ag, err := NewAccountsGetter(&mocks.RestClientStub{
-		CallPostRestEndPointCalled: func(path string, dataD interface{}, response interface{}, authenticationData data.RestApiAuthenticationData) error {
+		CallPostRestEndPointCalled: func(ctx context.Context, path string, dataD interface{}, response interface{}, authenticationData data.RestApiAuthenticationData) error {
 			responseVmValue := response.(*data.ResponseVmValue)
 			responseVmValue.Data = data.VmValuesResponseData{
 				Data: &vm.VMOutputApi{
@@ -41,7 +42,7 @@ func TestAccountsGetter_ValidatorsAccountsPutUnDelegatedValues(t *testing.T) {
 	err = json.Unmarshal([]byte(accountsWithStakeJson), &accountsWithStake)
 	require.Nil(t, err)
 
-	err = ag.putUndelegatedValuesFromValidatorsContract(accountsWithStake)
+	err = ag.putUndelegatedValuesFromValidatorsContract(context.Background(), accountsWithStake, nil)
 	require.Nil(t, err)
 
 	for _, account := range accountsWithStake {
@@ -2,57 +2,148 @@ package restClient
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
-	"math"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	logger "github.com/multiversx/mx-chain-logger-go"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
 	"github.com/multiversx/mx-chain-tools-accounts-manager-go/core"
 	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/metrics"
 )
 
-const maxNumOfRetries = 10
+const (
+	userAgent = "Accounts manager>"
+
+	defaultMaxAttempts              = 5
+	defaultRequestTimeoutInSeconds  = 60
+	defaultOverallTimeoutInSeconds  = 600
+	defaultInitialBackoffInMillis   = 500
+	defaultMaxBackoffInSeconds      = 30
+	defaultMaxGatewayFailures       = 3
+	defaultGatewayCooldownInSeconds = 60
+
+	minAddressSegmentLength = 40
+)
 
 var log = logger.GetOrCreate("restClient")
 
 type restClient struct {
-	httpClient *http.Client
-	url        string
+	httpClient     *http.Client
+	gateways       *gatewaysSelector
+	overallTimeout time.Duration
+	retryPolicy    *retryPolicy
 }
 
-// NewRestClient will create a new instance of restClient
-func NewRestClient(url string) (*restClient, error) {
-	c := http.DefaultClient
+// NewRestClient will create a new instance of restClient. The zero values from the provided config are replaced
+// with the default ones. The requests are sent to the gateways from the URLs list, or to the URL if the list is empty
+func NewRestClient(cfg config.APIConfig) (*restClient, error) {
+	cfg = applyDefaults(cfg)
+	urls := GetGatewaysURLs(cfg)
+	if len(urls) == 0 {
+		return nil, ErrNoGatewayURL
+	}
+	if cfg.MaxAttempts < 1 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidMaxAttempts, cfg.MaxAttempts)
+	}
+	if cfg.RequestTimeoutInSeconds < 0 || cfg.OverallTimeoutInSeconds < 0 {
+		return nil, ErrInvalidTimeout
+	}
+	if cfg.InitialBackoffInMilliseconds < 0 || cfg.MaxBackoffInSeconds < 0 {
+		return nil, ErrInvalidBackoff
+	}
+	if cfg.MaxGatewayFailures < 1 || cfg.GatewayCooldownInSeconds < 0 {
+		return nil, ErrInvalidGatewayHealthCheck
+	}
 
 	return &restClient{
-		httpClient: c,
-		url:        url,
+		httpClient: newHTTPClient(time.Duration(cfg.RequestTimeoutInSeconds) * time.Second),
+		gateways: newGatewaysSelector(
+			urls,
+			cfg.MaxGatewayFailures,
+			time.Duration(cfg.GatewayCooldownInSeconds)*time.Second,
+		),
+		overallTimeout: time.Duration(cfg.OverallTimeoutInSeconds) * time.Second,
+		retryPolicy: newRetryPolicy(
+			cfg.MaxAttempts,
+			time.Duration(cfg.InitialBackoffInMilliseconds)*time.Millisecond,
+			time.Duration(cfg.MaxBackoffInSeconds)*time.Second,
+		),
 	}, nil
 }
 
+// newHTTPClient will create an http client that waits at most the provided timeout for the response headers of an
+// attempt. The read of the body is bounded only by the overall timeout of the call, so that a large response, such as
+// the delegated info, is not cut while it is decoded
+func newHTTPClient(requestTimeout time.Duration) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ResponseHeaderTimeout = requestTimeout
+
+	return &http.Client{
+		Transport: transport,
+	}
+}
+
+func applyDefaults(cfg config.APIConfig) config.APIConfig {
+	if cfg.MaxAttempts == 0 {
+		cfg.MaxAttempts = defaultMaxAttempts
+	}
+	if cfg.RequestTimeoutInSeconds == 0 {
+		cfg.RequestTimeoutInSeconds = defaultRequestTimeoutInSeconds
+	}
+	if cfg.OverallTimeoutInSeconds == 0 {
+		cfg.OverallTimeoutInSeconds = defaultOverallTimeoutInSeconds
+	}
+	if cfg.InitialBackoffInMilliseconds == 0 {
+		cfg.InitialBackoffInMilliseconds = defaultInitialBackoffInMillis
+	}
+	if cfg.MaxBackoffInSeconds == 0 {
+		cfg.MaxBackoffInSeconds = defaultMaxBackoffInSeconds
+	}
+	if cfg.MaxGatewayFailures == 0 {
+		cfg.MaxGatewayFailures = defaultMaxGatewayFailures
+	}
+	if cfg.GatewayCooldownInSeconds == 0 {
+		cfg.GatewayCooldownInSeconds = defaultGatewayCooldownInSeconds
+	}
+
+	return cfg
+}
+
+// GetGatewaysURLs returns the gateways from the URLs list, or the URL if the list is empty
+func GetGatewaysURLs(cfg config.APIConfig) []string {
+	urls := make([]string, 0, len(cfg.URLs)+1)
+	for _, url := range cfg.URLs {
+		if url != "" {
+			urls = append(urls, url)
+		}
+	}
+	if len(urls) == 0 && cfg.URL != "" {
+		urls = append(urls, cfg.URL)
+	}
+
+	return urls
+}
+
 // CallGetRestEndPoint calls an external end point (sends a get request)
 func (rc *restClient) CallGetRestEndPoint(
+	ctx context.Context,
 	path string,
 	value interface{},
 	authenticationData data.RestApiAuthenticationData,
 ) error {
-	req, err := http.NewRequest("GET", rc.url+path, nil)
-	if err != nil {
-		return err
-	}
+	ctx, cancel := rc.withOverallTimeout(ctx)
+	defer cancel()
 
-	userAgent := "Accounts manager>"
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("User-Agent", userAgent)
-	if core.ShouldUseBasicAuthentication(authenticationData) {
-		req.SetBasicAuth(authenticationData.Username, authenticationData.Password)
-	}
-
-	resp, err := rc.httpClient.Do(req)
+	resp, err := rc.doWithRetries(ctx, http.MethodGet, path, nil, authenticationData)
 	if err != nil {
 		return err
 	}
@@ -74,6 +165,7 @@ func (rc *restClient) CallGetRestEndPoint(
 
 // CallPostRestEndPoint calls an external end point (sends a post request)
 func (rc *restClient) CallPostRestEndPoint(
+	ctx context.Context,
 	path string,
 	dataR interface{},
 	response interface{},
@@ -84,44 +176,14 @@ func (rc *restClient) CallPostRestEndPoint(
 		return err
 	}
 
-	req, err := http.NewRequest("POST", rc.url+path, bytes.NewReader(buff))
+	ctx, cancel := rc.withOverallTimeout(ctx)
+	defer cancel()
+
+	resp, err := rc.doWithRetries(ctx, http.MethodPost, path, buff, authenticationData)
 	if err != nil {
 		return err
 	}
 
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("User-Agent", "userAgent")
-	if core.ShouldUseBasicAuthentication(authenticationData) {
-		req.SetBasicAuth(authenticationData.Username, authenticationData.Password)
-	}
-
-	var count int
-	var resp *http.Response
-	for {
-		resp, err = rc.httpClient.Do(req)
-
-		if err != nil {
-			if count < maxNumOfRetries {
-				log.Warn("rc.httpClient.Do", "error", err)
-				count++
-				sleep(count)
-				continue
-			}
-			return fmt.Errorf("too many retries, error: %w", err)
-		}
-
-		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusRequestTimeout {
-			_ = resp.Body.Close()
-			if count < maxNumOfRetries {
-				count++
-				sleep(count)
-				continue
-			}
-		}
-
-		break
-	}
-
 	defer func() {
 		errNotCritical := resp.Body.Close()
 		if errNotCritical != nil {
@@ -149,7 +211,119 @@ func (rc *restClient) CallPostRestEndPoint(
 	return errors.New(genericApiResponse.Error)
 }
 
-func sleep(count int) {
-	delay := time.Duration(math.Exp2(float64(count))) * time.Second
-	time.Sleep(delay)
+// withOverallTimeout bounds a call, including all its attempts and the read of the response
+func (rc *restClient) withOverallTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if rc.overallTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, rc.overallTimeout)
+}
+
+// doWithRetries sends the request until it succeeds, the attempts are exhausted or the overall timeout expires. A new
+// request is built for every attempt, so the body is sent again. The caller must close the body of the response
+func (rc *restClient) doWithRetries(
+	ctx context.Context,
+	method string,
+	path string,
+	body []byte,
+	authenticationData data.RestApiAuthenticationData,
+) (*http.Response, error) {
+	pathLabel := metricsPath(path)
+	for attempt := 1; ; attempt++ {
+		gatewayURL := rc.gateways.pick()
+		start := time.Now()
+		resp, err := rc.do(ctx, gatewayURL, method, path, body, authenticationData)
+		if err != nil {
+			metrics.IncRestRequest(pathLabel, metrics.StatusNetworkError)
+		} else {
+			metrics.IncRestRequest(pathLabel, strconv.Itoa(resp.StatusCode))
+		}
+
+		retryAfter := time.Duration(0)
+		switch {
+		case err != nil:
+			if ctx.Err() != nil {
+				return nil, err
+			}
+			rc.gateways.reportFailure(gatewayURL)
+		case isRetriableStatus(resp.StatusCode):
+			rc.gateways.reportFailure(gatewayURL)
+			retryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+			if attempt >= rc.retryPolicy.maxAttempts {
+				// the last response is returned, so the caller can extract the error from it
+				return resp, nil
+			}
+			_ = resp.Body.Close()
+			err = fmt.Errorf("status code %d", resp.StatusCode)
+		default:
+			rc.gateways.reportSuccess(gatewayURL)
+			log.Debug("restClient: request served", "gateway", gatewayURL, "method", method, "path", path,
+				"status", resp.StatusCode, "duration", time.Since(start))
+			return resp, nil
+		}
+
+		if attempt >= rc.retryPolicy.maxAttempts {
+			return nil, fmt.Errorf("too many retries, error: %w", err)
+		}
+
+		metrics.IncRestRetry(pathLabel)
+		delay := rc.retryPolicy.backoff(attempt-1, retryAfter)
+		log.Warn("restClient: request failed, retrying", "gateway", gatewayURL, "method", method, "path", path,
+			"attempt", attempt, "error", err, "retry in", delay)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("%w while retrying, last error: %s", ctx.Err(), err.Error())
+		case <-timer.C:
+		}
+	}
+}
+
+// metricsPath removes the query and replaces the nonces and the addresses from the provided path, so the number of
+// distinct path labels stays bounded
+func metricsPath(path string) string {
+	path = strings.SplitN(path, "?", 2)[0]
+
+	segments := strings.Split(path, "/")
+	for idx, segment := range segments {
+		_, errParse := strconv.ParseUint(segment, 10, 64)
+		switch {
+		case errParse == nil:
+			segments[idx] = "{number}"
+		case len(segment) >= minAddressSegmentLength:
+			segments[idx] = "{address}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+func (rc *restClient) do(
+	ctx context.Context,
+	gatewayURL string,
+	method string,
+	path string,
+	body []byte,
+	authenticationData data.RestApiAuthenticationData,
+) (*http.Response, error) {
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, gatewayURL+path, bodyReader)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	if core.ShouldUseBasicAuthentication(authenticationData) {
+		req.SetBasicAuth(authenticationData.Username, authenticationData.Password)
+	}
+
+	return rc.httpClient.Do(req)
 }
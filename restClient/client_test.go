@@ -0,0 +1,241 @@
+package restClient
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestClient(t *testing.T, url string, maxAttempts int) *restClient {
+	rc, err := NewRestClient(config.APIConfig{
+		URL:                          url,
+		MaxAttempts:                  maxAttempts,
+		InitialBackoffInMilliseconds: 1,
+		MaxBackoffInSeconds:          1,
+	})
+	require.Nil(t, err)
+
+	return rc
+}
+
+func TestNewRestClient(t *testing.T) {
+	t.Parallel()
+
+	rc, err := NewRestClient(config.APIConfig{URL: "http://localhost", MaxAttempts: -1})
+	require.Nil(t, rc)
+	require.ErrorIs(t, err, ErrInvalidMaxAttempts)
+
+	rc, err = NewRestClient(config.APIConfig{URL: "http://localhost", RequestTimeoutInSeconds: -1})
+	require.Nil(t, rc)
+	require.Equal(t, ErrInvalidTimeout, err)
+
+	rc, err = NewRestClient(config.APIConfig{})
+	require.Nil(t, rc)
+	require.Equal(t, ErrNoGatewayURL, err)
+
+	rc, err = NewRestClient(config.APIConfig{URL: "http://localhost"})
+	require.Nil(t, err)
+	require.Equal(t, defaultMaxAttempts, rc.retryPolicy.maxAttempts)
+	require.Equal(t, time.Duration(0), rc.httpClient.Timeout)
+	require.Equal(t, defaultRequestTimeoutInSeconds*time.Second, rc.httpClient.Transport.(*http.Transport).ResponseHeaderTimeout)
+}
+
+func TestRestClient_RequestTimeoutShouldNotCutTheBody(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		time.Sleep(200 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"data":{"value":1}}`))
+	}))
+	defer server.Close()
+
+	rc := createTestClient(t, server.URL, 1)
+	rc.httpClient = newHTTPClient(50 * time.Millisecond)
+
+	response := &data.GenericAPIResponse{}
+	err := rc.CallGetRestEndPoint(context.Background(), "/path", response, data.RestApiAuthenticationData{})
+	require.Nil(t, err)
+	require.Equal(t, `{"value":1}`, string(response.Data))
+}
+
+func TestRestClient_RequestTimeoutShouldBoundTheWaitForTheHeaders(t *testing.T) {
+	t.Parallel()
+
+	numCalls := int32(0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&numCalls, 1) == 1 {
+			time.Sleep(200 * time.Millisecond)
+		}
+		_, _ = w.Write([]byte(`{"data":{"value":1}}`))
+	}))
+	defer server.Close()
+
+	rc := createTestClient(t, server.URL, 2)
+	rc.httpClient = newHTTPClient(50 * time.Millisecond)
+
+	response := &data.GenericAPIResponse{}
+	err := rc.CallGetRestEndPoint(context.Background(), "/path", response, data.RestApiAuthenticationData{})
+	require.Nil(t, err)
+	require.Equal(t, int32(2), atomic.LoadInt32(&numCalls))
+}
+
+func TestRestClient_CallGetRestEndPointRetriesServerErrors(t *testing.T) {
+	t.Parallel()
+
+	numCalls := int32(0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&numCalls, 1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"data":{"value":1}}`))
+	}))
+	defer server.Close()
+
+	rc := createTestClient(t, server.URL, 5)
+	response := &data.GenericAPIResponse{}
+	err := rc.CallGetRestEndPoint(context.Background(), "/path", response, data.RestApiAuthenticationData{})
+	require.Nil(t, err)
+	require.Equal(t, `{"value":1}`, string(response.Data))
+	require.Equal(t, int32(3), atomic.LoadInt32(&numCalls))
+}
+
+func TestRestClient_CallPostRestEndPointResendsTheBody(t *testing.T) {
+	t.Parallel()
+
+	numCalls := int32(0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		require.Equal(t, `{"key":"value"}`, string(body))
+
+		if atomic.AddInt32(&numCalls, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"data":{}}`))
+	}))
+	defer server.Close()
+
+	rc := createTestClient(t, server.URL, 2)
+	err := rc.CallPostRestEndPoint(context.Background(), "/path", map[string]string{"key": "value"}, &data.GenericAPIResponse{}, data.RestApiAuthenticationData{})
+	require.Nil(t, err)
+	require.Equal(t, int32(2), atomic.LoadInt32(&numCalls))
+}
+
+func TestRestClient_CallPostRestEndPointReturnsTheLastError(t *testing.T) {
+	t.Parallel()
+
+	numCalls := int32(0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&numCalls, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"error":"internal error"}`))
+	}))
+	defer server.Close()
+
+	rc := createTestClient(t, server.URL, 3)
+	err := rc.CallPostRestEndPoint(context.Background(), "/path", nil, &data.GenericAPIResponse{}, data.RestApiAuthenticationData{})
+	require.EqualError(t, err, "internal error")
+	require.Equal(t, int32(3), atomic.LoadInt32(&numCalls))
+}
+
+func TestRestClient_DoesNotRetryClientErrors(t *testing.T) {
+	t.Parallel()
+
+	numCalls := int32(0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&numCalls, 1)
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":"bad request"}`))
+	}))
+	defer server.Close()
+
+	rc := createTestClient(t, server.URL, 3)
+	err := rc.CallPostRestEndPoint(context.Background(), "/path", nil, &data.GenericAPIResponse{}, data.RestApiAuthenticationData{})
+	require.EqualError(t, err, "bad request")
+	require.Equal(t, int32(1), atomic.LoadInt32(&numCalls))
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	t.Parallel()
+
+	rp := newRetryPolicy(5, 100*time.Millisecond, time.Second)
+	for attempt := 0; attempt < 40; attempt++ {
+		delay := rp.backoff(attempt, 0)
+		require.True(t, delay > 0 && delay <= time.Second)
+	}
+	require.True(t, rp.backoff(0, 0) <= 100*time.Millisecond)
+	require.Equal(t, 500*time.Millisecond, rp.backoff(0, 500*time.Millisecond))
+	require.Equal(t, time.Second, rp.backoff(0, time.Hour))
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	require.Equal(t, time.Duration(0), parseRetryAfter("", now))
+	require.Equal(t, time.Duration(0), parseRetryAfter("invalid", now))
+	require.Equal(t, 3*time.Second, parseRetryAfter("3", now))
+
+	date := now.Add(10 * time.Second).UTC().Format(http.TimeFormat)
+	delay := parseRetryAfter(date, now)
+	require.True(t, delay > 8*time.Second && delay <= 10*time.Second)
+}
+
+func TestRestClient_FailsOverToTheNextGateway(t *testing.T) {
+	t.Parallel()
+
+	numCallsBroken := int32(0)
+	brokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&numCallsBroken, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer brokenServer.Close()
+
+	numCallsHealthy := int32(0)
+	healthyServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&numCallsHealthy, 1)
+		_, _ = w.Write([]byte(`{"data":{}}`))
+	}))
+	defer healthyServer.Close()
+
+	rc, err := NewRestClient(config.APIConfig{
+		URLs:                         []string{brokenServer.URL, healthyServer.URL},
+		MaxAttempts:                  3,
+		InitialBackoffInMilliseconds: 1,
+		MaxBackoffInSeconds:          1,
+		MaxGatewayFailures:           1,
+	})
+	require.Nil(t, err)
+
+	for i := 0; i < 3; i++ {
+		err = rc.CallGetRestEndPoint(context.Background(), "/path", &data.GenericAPIResponse{}, data.RestApiAuthenticationData{})
+		require.Nil(t, err)
+	}
+
+	// the broken gateway is not used again after it was marked as unhealthy
+	require.Equal(t, int32(1), atomic.LoadInt32(&numCallsBroken))
+	require.Equal(t, int32(3), atomic.LoadInt32(&numCallsHealthy))
+}
+
+func TestMetricsPath(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "/network/status/{number}", metricsPath("/network/status/4294967295"))
+	require.Equal(t, "/vm-values/query", metricsPath("/vm-values/query?blockNonce=12"))
+	require.Equal(t, "/address/{address}/keys", metricsPath("/address/erd1qqqqqqqqqqqqqpgqxwakt2g7u9atsnr03gqcgmhcv38pt7mkd94q6shuwt/keys?blockNonce=3"))
+	require.Equal(t, "/hyperblock/by-nonce/{number}", metricsPath("/hyperblock/by-nonce/100"))
+}
@@ -0,0 +1,18 @@
+package restClient
+
+import "errors"
+
+// ErrNoGatewayURL signals that no gateway URL has been provided
+var ErrNoGatewayURL = errors.New("no gateway URL")
+
+// ErrInvalidGatewayHealthCheck signals that an invalid gateway health check config has been provided
+var ErrInvalidGatewayHealthCheck = errors.New("invalid gateway health check config")
+
+// ErrInvalidMaxAttempts signals that an invalid number of attempts has been provided
+var ErrInvalidMaxAttempts = errors.New("invalid max attempts")
+
+// ErrInvalidTimeout signals that an invalid timeout has been provided
+var ErrInvalidTimeout = errors.New("invalid timeout")
+
+// ErrInvalidBackoff signals that an invalid backoff has been provided
+var ErrInvalidBackoff = errors.New("invalid backoff")
@@ -0,0 +1,104 @@
+package restClient
+
+import (
+	"sync"
+	"time"
+)
+
+type gateway struct {
+	url            string
+	numFailures    int
+	unhealthyUntil time.Time
+}
+
+// gatewaysSelector keeps using the same gateway for as long as it is healthy, so that all the requests of a snapshot
+// are served by the same gateway. A gateway becomes unhealthy after a number of consecutive failures and it is not
+// selected again until its cooldown expires
+type gatewaysSelector struct {
+	mutex       sync.Mutex
+	gateways    []*gateway
+	current     int
+	maxFailures int
+	cooldown    time.Duration
+	now         func() time.Time
+}
+
+func newGatewaysSelector(urls []string, maxFailures int, cooldown time.Duration) *gatewaysSelector {
+	gateways := make([]*gateway, 0, len(urls))
+	for _, url := range urls {
+		gateways = append(gateways, &gateway{url: url})
+	}
+
+	return &gatewaysSelector{
+		gateways:    gateways,
+		maxFailures: maxFailures,
+		cooldown:    cooldown,
+		now:         time.Now,
+	}
+}
+
+// pick returns the gateway that should serve the next request. If all the gateways are unhealthy, the one that
+// recovers first is returned
+func (gs *gatewaysSelector) pick() string {
+	gs.mutex.Lock()
+	defer gs.mutex.Unlock()
+
+	now := gs.now()
+	for offset := 0; offset < len(gs.gateways); offset++ {
+		idx := (gs.current + offset) % len(gs.gateways)
+		if !gs.gateways[idx].unhealthyUntil.After(now) {
+			gs.current = idx
+			return gs.gateways[idx].url
+		}
+	}
+
+	firstToRecover := 0
+	for idx, gw := range gs.gateways {
+		if gw.unhealthyUntil.Before(gs.gateways[firstToRecover].unhealthyUntil) {
+			firstToRecover = idx
+		}
+	}
+
+	return gs.gateways[firstToRecover].url
+}
+
+func (gs *gatewaysSelector) reportSuccess(url string) {
+	gs.mutex.Lock()
+	defer gs.mutex.Unlock()
+
+	gw := gs.getGateway(url)
+	if gw != nil {
+		gw.numFailures = 0
+	}
+}
+
+func (gs *gatewaysSelector) reportFailure(url string) {
+	gs.mutex.Lock()
+	defer gs.mutex.Unlock()
+
+	gw := gs.getGateway(url)
+	if gw == nil {
+		return
+	}
+
+	gw.numFailures++
+	if gw.numFailures < gs.maxFailures {
+		return
+	}
+
+	gw.numFailures = 0
+	gw.unhealthyUntil = gs.now().Add(gs.cooldown)
+	if len(gs.gateways) > 1 {
+		log.Warn("gateway marked as unhealthy", "gateway", url, "cooldown", gs.cooldown)
+	}
+}
+
+func (gs *gatewaysSelector) getGateway(url string) *gateway {
+	for _, gw := range gs.gateways {
+		if gw.url == url {
+			return gw
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package restClient
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGatewaysSelector_StickySelectionAndFailover(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	gs := newGatewaysSelector([]string{"a", "b", "c"}, 2, time.Minute)
+	gs.now = func() time.Time {
+		return now
+	}
+
+	require.Equal(t, "a", gs.pick())
+	gs.reportFailure("a")
+	// a single failure is not enough to mark the gateway as unhealthy
+	require.Equal(t, "a", gs.pick())
+	gs.reportSuccess("a")
+	gs.reportFailure("a")
+	require.Equal(t, "a", gs.pick())
+	gs.reportFailure("a")
+	require.Equal(t, "b", gs.pick())
+
+	// the selection stays on b even after a recovers
+	now = now.Add(2 * time.Minute)
+	require.Equal(t, "b", gs.pick())
+}
+
+func TestGatewaysSelector_AllUnhealthy(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	gs := newGatewaysSelector([]string{"a", "b"}, 1, time.Minute)
+	gs.now = func() time.Time {
+		return now
+	}
+
+	gs.reportFailure("a")
+	now = now.Add(time.Second)
+	gs.reportFailure("b")
+
+	// the gateway that recovers first is used
+	require.Equal(t, "a", gs.pick())
+}
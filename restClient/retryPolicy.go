@@ -0,0 +1,83 @@
+package restClient
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type retryPolicy struct {
+	maxAttempts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+
+	mutRand sync.Mutex
+	rand    *rand.Rand
+}
+
+func newRetryPolicy(maxAttempts int, initialBackoff time.Duration, maxBackoff time.Duration) *retryPolicy {
+	return &retryPolicy{
+		maxAttempts:    maxAttempts,
+		initialBackoff: initialBackoff,
+		maxBackoff:     maxBackoff,
+		rand:           rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// backoff returns the delay before the next attempt. The delay grows exponentially with the number of attempts, it
+// is capped to the max backoff and a random jitter is applied so that concurrent callers do not retry in lockstep.
+// A delay requested by the server with the Retry-After header takes precedence, but it is capped to the max backoff as
+// well, so that a misbehaving gateway cannot stall the run
+func (rp *retryPolicy) backoff(attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > rp.maxBackoff {
+		return rp.maxBackoff
+	}
+	if retryAfter > 0 {
+		return retryAfter
+	}
+
+	delay := rp.maxBackoff
+	if attempt < 32 {
+		exponential := rp.initialBackoff << uint(attempt)
+		if exponential > 0 && exponential < rp.maxBackoff {
+			delay = exponential
+		}
+	}
+
+	rp.mutRand.Lock()
+	jitter := rp.rand.Float64()
+	rp.mutRand.Unlock()
+
+	// equal jitter: half of the delay is kept, the other half is random
+	return delay/2 + time.Duration(jitter*float64(delay/2))
+}
+
+func isRetriableStatus(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests ||
+		statusCode == http.StatusRequestTimeout ||
+		statusCode >= http.StatusInternalServerError
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which holds either a number of seconds or an HTTP date
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	seconds, err := strconv.Atoi(value)
+	if err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil || !date.After(now) {
+		return 0
+	}
+
+	return date.Sub(now)
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	dataIndexer "github.com/multiversx/mx-chain-es-indexer-go/data"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
 	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
 	"github.com/multiversx/mx-chain-tools-accounts-manager-go/elasticClient"
 	"github.com/multiversx/mx-chain-tools-accounts-manager-go/process"
@@ -32,7 +33,7 @@ func TestIndex(t *testing.T) {
 }
 
 func generateAccountsAndIndex(t *testing.T, numberOfAccounts int, handler process.ElasticClientHandler) {
-	ap, _ := accountsIndexer.NewAccountsIndexer(handler)
+	ap, _ := accountsIndexer.NewAccountsIndexer(handler, config.BulkIndexerConfig{})
 	err := ap.IndexAccounts(generateAccounts(numberOfAccounts), "accounts-000001")
 	require.Nil(t, err)
 